@@ -68,7 +68,7 @@ func main() {
 		// make tree.Items from cidrs
 		items := make([]tree.Item, len(cidrs))
 		for i, c := range cidrs {
-			items[i] = tree.Item{c, nil, nil}
+			items[i] = tree.Item{Block: c}
 		}
 		tr := tree.New()
 		tr.Insert(items...)
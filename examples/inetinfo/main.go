@@ -7,7 +7,6 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -82,9 +81,11 @@ func printBlockInfo(block inet.Block) {
 		fmt.Printf("%-10s %v\n", "Base:", block.Base)
 		fmt.Printf("%-10s %v\n", "Last:", block.Last)
 		fmt.Printf("%-10s %v\n", "Mask:", block.Mask)
-		fmt.Printf("%-10s %v\n", "Wildcard:", hostmask(block.Mask))
+		fmt.Printf("%-10s %v\n", "Wildcard:", block.Wildcard())
 		fmt.Printf("%-10s %v bits\n", "Bits:", block.BitLen())
-		fmt.Printf("%-10s %v addrs\n", "Size:", block.Size())
+		fmt.Printf("%-10s %v addrs\n", "Size:", sizeHint(block))
+		printHostInfo(block)
+		fmt.Printf("%-10s %v\n", "Zones:", zoneHint(block))
 	} else {
 		fmt.Printf("%-10s %v-%v\n", "Range:", block.Base, block.Last)
 		fmt.Printf("%-10s %v bits (min)\n", "Bits:", block.BitLen())
@@ -92,14 +93,44 @@ func printBlockInfo(block inet.Block) {
 	}
 }
 
-// helper
-func hostmask(netmask inet.IP) inet.IP {
-	nm := netmask.Bytes()
-	hostmask := make([]byte, len(nm))
-	for i := range nm {
-		hostmask[i] = ^nm[i]
+// helper for the usable hosts of a CIDR
+func printHostInfo(cidr inet.Block) {
+	hosts, _ := cidr.HostRange()
+	fmt.Printf("%-10s %v-%v\n", "Hosts:", hosts.Base, hosts.Last)
+	fmt.Printf("%-10s %v addrs\n", "Usable:", cidr.NumHosts())
+
+	if cidr.IsPointToPoint() {
+		rfc := "RFC 3021"
+		if cidr.Version() == 6 {
+			rfc = "RFC 6164"
+		}
+		fmt.Printf("%-10s point-to-point link, %s\n", "Note:", rfc)
+	}
+
+	if ip, ok := cidr.Broadcast(); ok {
+		fmt.Printf("%-10s %v\n", "Broadcast:", ip)
+	}
+
+	if ip, ok := cidr.SubnetRouterAnycast(); ok {
+		fmt.Printf("%-10s %v\n", "Anycast:", ip)
+	}
+}
+
+// helper for the reverse DNS zones, abbreviated for many zones
+func zoneHint(cidr inet.Block) string {
+	zones := cidr.ReverseZones()
+	if len(zones) <= 2 {
+		return strings.Join(zones, ", ")
+	}
+	return fmt.Sprintf("%s ... %s (%d zones)", zones[0], zones[len(zones)-1], len(zones))
+}
+
+// helper for the CIDR size, with 2^n notation for big blocks
+func sizeHint(cidr inet.Block) string {
+	if n, ok := cidr.SizeUint64(); ok && n <= 1<<32 {
+		return cidr.Size()
 	}
-	return inet.MustIP(hostmask)
+	return fmt.Sprintf("2^%d = %s", cidr.BitLen(), cidr.Size())
 }
 
 // helper for CIDR v6 representation hints:
@@ -110,7 +141,7 @@ func hostmask(netmask inet.IP) inet.IP {
 //
 func cidrHints(cidr inet.Block) string {
 	// get the bits from mask
-	bits, _ := net.IPMask(cidr.Mask.Bytes()).Size()
+	bits, _ := cidr.PrefixLen()
 
 	// expand the base IP
 	base := cidr.Base.Expand()
@@ -172,6 +203,10 @@ Mask:      ffff:ffff:ffff:ffff:ffff:ffff:ffff:f000
 Wildcard:  ::fff
 Bits:      12 bits
 Size:      4096 addrs
+Hosts:     2001:db8:c::1-2001:db8:c::fff
+Usable:    4095 addrs
+Anycast:   2001:db8:c::
+Zones:     0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.c.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa
 `
 	fmt.Fprint(w, output)
 	os.Exit(1)
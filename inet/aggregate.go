@@ -0,0 +1,73 @@
+package inet
+
+import (
+	"bytes"
+	"errors"
+)
+
+var errUnsorted = errors.New("Blocks not sorted")
+
+// Aggregator aggregates a stream of blocks to the minimal number of CIDRs with constant memory.
+// The blocks must be added sorted by Base, see SortBlock. Merged CIDRs are passed to the emit
+// callback as soon as a gap in the stream is detected, in sorted order.
+//
+//  ag := NewAggregator(func(cidr Block) { fmt.Println(cidr) })
+//  for _, b := range sorted {
+//    if err := ag.Add(b); err != nil {
+//      ...
+//    }
+//  }
+//  ag.Flush()
+//
+// For unsorted input use Aggregate.
+type Aggregator struct {
+	emit    func(Block)
+	pending Block
+}
+
+// NewAggregator returns a new Aggregator, emit is called for every aggregated CIDR.
+func NewAggregator(emit func(Block)) *Aggregator {
+	return &Aggregator{emit: emit}
+}
+
+// Add adds the next block of the stream. Returns an error for invalid blocks
+// or if b.Base is less than the Base of the previous added block.
+func (g *Aggregator) Add(b Block) error {
+	if !b.IsValid() {
+		return errInvalidBlock
+	}
+
+	// first block or after Flush
+	if g.pending == (Block{}) {
+		g.pending = b
+		return nil
+	}
+
+	if bytes.Compare(b.Base[:], g.pending.Base[:]) < 0 {
+		return errUnsorted
+	}
+
+	// overlapping or adjacent, no gap between pending and b
+	if bytes.Compare(b.Base[:], g.pending.Last[:]) <= 0 || isAdjacent(g.pending.Last, b.Base) {
+		if bytes.Compare(b.Last[:], g.pending.Last[:]) > 0 {
+			g.pending.Last = b.Last
+		}
+		return nil
+	}
+
+	g.Flush()
+	g.pending = b
+	return nil
+}
+
+// Flush emits the pending CIDRs, call it after the last block is added.
+func (g *Aggregator) Flush() {
+	if g.pending == (Block{}) {
+		return
+	}
+
+	for _, cidr := range newRange(g.pending.Base, g.pending.Last).BlockToCIDRList() {
+		g.emit(cidr)
+	}
+	g.pending = Block{}
+}
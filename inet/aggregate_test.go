@@ -0,0 +1,155 @@
+package inet
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestAggregator(t *testing.T) {
+	in := blocks(
+		"10.0.0.0/32",
+		"10.0.0.1/32",
+		"10.0.0.4/30",
+		"10.0.0.5-10.0.0.9",
+		"10.0.0.7-10.0.0.99",
+		"10.0.0.16/28",
+		"10.1.0.0/16",
+		"255.255.255.255",
+		"::",
+		"fe80::/12",
+		"fe80:0000:0000:0000:fe2d:5eff:fef0:fc64/128",
+		"fe80::/10",
+	)
+	SortBlock(in)
+
+	var got []Block
+	ag := NewAggregator(func(cidr Block) { got = append(got, cidr) })
+	for _, b := range in {
+		if err := ag.Add(b); err != nil {
+			t.Fatalf("Add(%v), unexpected error: %v", b, err)
+		}
+	}
+	ag.Flush()
+
+	want := Aggregate(in)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Aggregator, got %v, want %v", got, want)
+	}
+
+	// Flush is idempotent
+	ag.Flush()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Aggregator, got %v after second Flush, want %v", got, want)
+	}
+}
+
+func TestAggregatorErrors(t *testing.T) {
+	ag := NewAggregator(func(Block) {})
+
+	if err := ag.Add(Block{}); err == nil {
+		t.Errorf("Add(Block{}), expected error, got nil")
+	}
+
+	if err := ag.Add(MustBlock("10.0.0.0/8")); err != nil {
+		t.Errorf("Add(10.0.0.0/8), unexpected error: %v", err)
+	}
+
+	if err := ag.Add(MustBlock("9.0.0.0/8")); err == nil {
+		t.Errorf("Add(9.0.0.0/8) after 10.0.0.0/8, expected error, got nil")
+	}
+}
+
+func TestAggregateRanges(t *testing.T) {
+	in := blocks(
+		"10.0.0.7-10.0.0.99",
+		"10.0.0.0/32",
+		"10.0.0.1/32",
+		"10.0.0.4/30",
+		"10.0.1.0/24",
+		"255.255.255.255",
+		"fe80::/12",
+		"fe80::/10",
+		"::",
+	)
+
+	want := blocks("10.0.0.0/31", "10.0.0.4-10.0.0.99", "10.0.1.0/24", "255.255.255.255/32", "::/128", "fe80::/10")
+	got := AggregateRanges(in)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AggregateRanges(%v) = %v, want %v", in, got, want)
+	}
+
+	v4, v6 := AggregateRangesByVersion(in)
+	if !reflect.DeepEqual(v4, want[:4]) || !reflect.DeepEqual(v6, want[4:]) {
+		t.Errorf("AggregateRangesByVersion(%v) = %v, %v, want %v, %v", in, v4, v6, want[:4], want[4:])
+	}
+
+	v4, v6 = AggregateRangesByVersion(blocks("fe80::/10"))
+	if v4 != nil || len(v6) != 1 {
+		t.Errorf("AggregateRangesByVersion(fe80::/10) = %v, %v, want [], [fe80::/10]", v4, v6)
+	}
+
+	if got := AggregateRanges(nil); got != nil {
+		t.Errorf("AggregateRanges(nil) = %v, want nil", got)
+	}
+}
+
+func TestAggregateRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	base := MustIP("10.0.0.0")
+
+	for i := 0; i < 200; i++ {
+		var in []Block
+		for n := r.Intn(8); n > 0; n-- {
+			lo, hi := uint64(r.Intn(256)), uint64(r.Intn(256))
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			in = append(in, newRange(base.AddUint64(lo), base.AddUint64(hi)))
+		}
+
+		got := Aggregate(in)
+		for j, cidr := range got {
+			if !cidr.IsCIDR() {
+				t.Fatalf("Aggregate(%v) = %v, %v is no CIDR", in, got, cidr)
+			}
+			if j > 0 && bytes.Compare(got[j-1].Last[:], cidr.Base[:]) >= 0 {
+				t.Fatalf("Aggregate(%v) = %v, not sorted or overlapping", in, got)
+			}
+		}
+
+		for k := uint64(0); k < 256; k++ {
+			ip := base.AddUint64(k)
+			if containsIP(got, ip) != containsIP(in, ip) {
+				t.Fatalf("Aggregate(%v) = %v, wrong for %v", in, got, ip)
+			}
+		}
+
+		// the stream gives the same result
+		sorted := append([]Block(nil), in...)
+		SortBlock(sorted)
+
+		var stream []Block
+		ag := NewAggregator(func(cidr Block) { stream = append(stream, cidr) })
+		for _, b := range sorted {
+			if err := ag.Add(b); err != nil {
+				t.Fatalf("Add(%v), unexpected error: %v", b, err)
+			}
+		}
+		ag.Flush()
+
+		if !reflect.DeepEqual(stream, got) {
+			t.Fatalf("Aggregator(%v) = %v, want %v", sorted, stream, got)
+		}
+	}
+}
+
+func containsIP(bs []Block, ip IP) bool {
+	for _, b := range bs {
+		if b.ContainsIP(ip) {
+			return true
+		}
+	}
+	return false
+}
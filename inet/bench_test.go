@@ -3,6 +3,7 @@ package inet_test
 import (
 	"fmt"
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/gaissmai/go-inet/inet"
@@ -40,3 +41,186 @@ func BenchmarkSortBlock(b *testing.B) {
 
 	}
 }
+
+func BenchmarkAggregate(b *testing.B) {
+	bench := []int{10000, 100000, 1000000}
+
+	for _, n := range bench {
+		rs := internal.GenRangeMixed(n)
+
+		b.Run(fmt.Sprintf("%7d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				inet.Aggregate(rs)
+			}
+		})
+	}
+}
+
+func BenchmarkAggregateMap(b *testing.B) {
+	// the map based implementation is too slow for larger inputs
+	bench := []int{1000, 10000}
+
+	for _, n := range bench {
+		rs := internal.GenRangeMixed(n)
+
+		b.Run(fmt.Sprintf("%7d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				aggregateMap(rs)
+			}
+		})
+	}
+}
+
+func BenchmarkAggregateSiblings(b *testing.B) {
+	bench := []int{1000, 10000, 100000}
+
+	for _, n := range bench {
+		rs := internal.GenSiblingV4(n)
+
+		b.Run(fmt.Sprintf("%7d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				inet.Aggregate(rs)
+			}
+		})
+	}
+}
+
+func BenchmarkAggregateMapSiblings(b *testing.B) {
+	bench := []int{1000, 10000, 100000}
+
+	for _, n := range bench {
+		rs := internal.GenSiblingV4(n)
+
+		b.Run(fmt.Sprintf("%7d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				aggregateMap(rs)
+			}
+		})
+	}
+}
+
+func BenchmarkAggregator(b *testing.B) {
+	bench := []int{10000, 100000, 1000000}
+
+	for _, n := range bench {
+		rs := internal.GenRangeMixed(n)
+		inet.SortBlock(rs)
+
+		b.Run(fmt.Sprintf("%7d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				ag := inet.NewAggregator(func(inet.Block) {})
+				for _, r := range rs {
+					_ = ag.Add(r)
+				}
+				ag.Flush()
+			}
+		})
+	}
+}
+
+// the reference implementation must give the same result, the random ranges
+// overlap and hardly merge to CIDRs, the siblings are mostly adjacent CIDRs
+func TestAggregateVersusMap(t *testing.T) {
+	inputs := [][]inet.Block{
+		{inet.MustBlock("10.0.0.0/24")},
+		{inet.MustBlock("10.0.0.0/25"), inet.MustBlock("10.0.0.128/25")},
+		{inet.MustBlock("10.0.0.0/24"), inet.MustBlock("10.0.1.0/24"), inet.MustBlock("10.0.3.0/24")},
+		{inet.MustBlock("255.255.255.250-255.255.255.255")},
+		internal.GenSiblingV4(1000),
+		internal.GenSiblingV4(10000),
+		internal.GenRangeMixed(1000),
+	}
+
+	for _, in := range inputs {
+		want := aggregateMap(in)
+		if got := inet.Aggregate(in); !reflect.DeepEqual(got, want) {
+			t.Errorf("Aggregate(%d blocks), got %d CIDRs, want %d", len(in), len(got), len(want))
+		}
+	}
+}
+
+// aggregateMap is the former implementation of Aggregate, kept for comparison:
+// expand to CIDRs, dedup in a map, sort, skip subsets and pack adjacent CIDRs.
+func aggregateMap(bs []inet.Block) []inet.Block {
+	set := map[inet.Block]bool{}
+	for i := range bs {
+		for _, cidr := range bs[i].BlockToCIDRList() {
+			set[cidr] = true
+		}
+	}
+
+	cidrs := make([]inet.Block, 0, len(set))
+	for cidr := range set {
+		cidrs = append(cidrs, cidr)
+	}
+	inet.SortBlock(cidrs)
+
+	// skip subsets
+	unique := make([]inet.Block, 0, len(cidrs))
+	for i := 0; i < len(cidrs); i++ {
+		super := cidrs[i]
+		unique = append(unique, super)
+		for i+1 < len(cidrs) && super.Contains(cidrs[i+1]) {
+			i++
+		}
+	}
+
+	// pack adjacent cidrs
+	var out []inet.Block
+	for i := 0; i < len(unique); i++ {
+		pack := unique[i]
+		for i+1 < len(unique) && pack.IsAdjacent(unique[i+1]) {
+			pack.Last = unique[i+1].Last
+			i++
+		}
+		// maybe it's no CIDR, just a range now
+		pack, _ = inet.ParseBlock(pack.Base.String() + "-" + pack.Last.String())
+		out = append(out, pack.BlockToCIDRList()...)
+	}
+
+	return out
+}
+
+func BenchmarkSummarize(b *testing.B) {
+	bench := []int{10000, 100000}
+
+	for _, n := range bench {
+		rs := internal.GenBlockMixed(n)
+
+		b.Run(fmt.Sprintf("%7d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _, _ = inet.Summarize(rs, 1000, nil)
+			}
+		})
+	}
+}
+
+func BenchmarkMemoryBlock(b *testing.B) {
+	rs := internal.GenBlockMixed(1000000)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		table := make([]inet.Block, len(rs))
+		copy(table, rs)
+	}
+}
+
+func BenchmarkMemoryCompactBlock(b *testing.B) {
+	rs := internal.GenBlockMixed(1000000)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		table := make([]inet.CompactBlock, len(rs))
+		for j := range rs {
+			table[j] = rs[j].Compact()
+		}
+	}
+}
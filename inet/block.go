@@ -6,11 +6,14 @@ import (
 	"math/big"
 	"net"
 	"sort"
+	"strconv"
 	"strings"
 )
 
 var (
-	errInvalidBlock = errors.New("invalid Block")
+	errInvalidBlock     = errors.New("invalid Block")
+	errInvalidPrefixLen = errors.New("invalid prefix length")
+	errNoCIDR           = errors.New("Block is no CIDR")
 )
 
 // Block is an IP-network or IP-range, e.g.
@@ -52,14 +55,30 @@ var (
 //
 //  "2001:db8:dead:/38"
 //  "10.0.0.0/8"
+//  "10/8"
+//  "192.168/16"
 //  "4.4.4.4"
 //
 //  "2001:db8::1-2001:db8::ff00:35"
 //  "192.168.2.3-192.168.7.255"
 //
+//  "10.0.0.0/255.0.0.0"     // netmask
+//  "10.0.0.0 255.0.0.0"     // netmask
+//  "10.0.0.0 0.255.255.255" // wildcard mask
+//
+// In the white space separated notation a mask with the most significant bit set is a netmask,
+// else a wildcard mask, "10.0.0.1 0.0.0.0" is the host 10.0.0.1/32. The all-ones mask is ambiguous
+// and rejected, like non-contiguous masks.
+//
 // If a begin-end range can be represented as a CIDR, ParseBlock() generates the netmask
 // and returns the range as CIDR.
 //
+// Abbreviated CIDRs as used in whois and RIR files are accepted, missing octets or hextets
+// are filled with zeros, "172.16/12" is 172.16.0.0/12 and "2001:db8:dead:/48" is 2001:db8:dead::/48.
+//
+// Host bits in CIDRs are masked off, "192.168.0.5/24" returns 192.168.0.0/24.
+// See ParseBlockStrict and IfAddr if the host bits matter.
+//
 // IP addresses as input are converted to /32 or /128 blocks
 // Returns error and Block{} on invalid input.
 func ParseBlock(i interface{}) (Block, error) {
@@ -77,6 +96,68 @@ func ParseBlock(i interface{}) (Block, error) {
 	}
 }
 
+// ParseBlockStrict is like ParseBlock, but rejects CIDRs with host bits set,
+// e.g. "192.168.0.5/24" is an error and not silently masked to 192.168.0.0/24.
+// The same holds for the mask notations "192.168.0.5/255.255.255.0" and "192.168.0.5 0.0.0.255".
+// Use ParseIfAddr for interface addresses.
+func ParseBlockStrict(i interface{}) (Block, error) {
+	var ifa IfAddr
+
+	switch v := i.(type) {
+	case string:
+		// mask notations, "addr/netmask" or "addr mask"
+		if i := strings.IndexByte(v, '/'); i >= 0 && strings.ContainsAny(v[i+1:], ".:") {
+			return blockFromMaskStrict(v, v[:i])
+		}
+		if fields := strings.Fields(v); len(fields) > 1 {
+			return blockFromMaskStrict(v, fields[0])
+		}
+
+		if strings.IndexByte(v, '/') < 0 {
+			return ParseBlock(v)
+		}
+		var err error
+		if ifa, err = splitIfAddr(v, ipFromAbbrev); err != nil {
+			return blockZero, err
+		}
+	case net.IPNet:
+		ip, err := ipFromNetIP(v.IP)
+		if err != nil {
+			return blockZero, errInvalidBlock
+		}
+		ones, bits := v.Mask.Size()
+		if bits != ip.bitLen() {
+			return blockZero, errInvalidBlock
+		}
+		ifa = IfAddr{Addr: ip, Bits: ones}
+	default:
+		return ParseBlock(i)
+	}
+
+	b := ifa.Block()
+	if b.Base != ifa.Addr {
+		return blockZero, errHostBits
+	}
+	return b, nil
+}
+
+// blockFromMaskStrict parses s in mask notation and rejects host bits in addr.
+func blockFromMaskStrict(s, addr string) (Block, error) {
+	b, err := blockFromString(s)
+	if err != nil {
+		return blockZero, err
+	}
+
+	ip, err := ipFromString(addr)
+	if err != nil {
+		return blockZero, errInvalidBlock
+	}
+	if ip != b.Base {
+		return blockZero, errHostBits
+	}
+	return b, nil
+}
+
 // MustBlock is a helper that calls ParseBlock and returns just inet.Block or panics on error.
 // It is intended for use in variable initializations.
 func MustBlock(i interface{}) Block {
@@ -103,6 +184,11 @@ func blockFromString(s string) (Block, error) {
 		return blockFromRange(s, i)
 	}
 
+	// address and netmask or wildcard mask, separated by white space
+	if strings.ContainsAny(s, " \t") {
+		return blockFromMaskString(s)
+	}
+
 	// maybe just an ip
 	ip, err := ipFromString(s)
 	if err == nil {
@@ -151,12 +237,94 @@ func blockFromNetIPNet(ipnet net.IPNet) (Block, error) {
 // parse IP CIDR
 // e.g.: 127.0.0.0/8 or 2001:db8::/32
 func blockFromCIDR(s string) (Block, error) {
-	_, netIPNet, err := net.ParseCIDR(s)
+	// netmask instead of prefix length, e.g.: 10.0.0.0/255.0.0.0
+	i := strings.IndexByte(s, '/')
+	if i >= 0 && strings.ContainsAny(s[i+1:], ".:") {
+		return ParseBlockWithMask(s[:i], s[i+1:])
+	}
+
+	ifa, err := splitIfAddr(s, ipFromAbbrev)
 	if err != nil {
 		return blockZero, err
 	}
 
-	return blockFromNetIPNet(*netIPNet)
+	return NewCIDR(ifa.Addr, ifa.Bits)
+}
+
+// ipFromAbbrev parses the address part of a CIDR, the address may be abbreviated
+// as in whois and RIR files, missing octets or hextets are filled with zeros:
+//
+//  10                 -> 10.0.0.0
+//  172.16             -> 172.16.0.0
+//  192.168.1          -> 192.168.1.0
+//  2001:db8:dead:     -> 2001:db8:dead::
+//  2001:db8           -> 2001:db8::
+func ipFromAbbrev(s string) (IP, error) {
+	ip, err := ipFromString(s)
+	if err == nil {
+		return ip, nil
+	}
+
+	if s == "" {
+		return ipZero, errInvalidIP
+	}
+
+	// IPv6, fill up missing hextets with "::"
+	if strings.IndexByte(s, ':') >= 0 {
+		// already compressed, nothing to fill up
+		if strings.Contains(s, "::") {
+			return ipZero, errInvalidIP
+		}
+
+		s = strings.TrimSuffix(s, ":")
+		if s == "" || strings.Count(s, ":") >= 7 {
+			return ipZero, errInvalidIP
+		}
+		return ipFromString(s + "::")
+	}
+
+	// IPv4, fill up missing octets with ".0"
+	octets := strings.Count(s, ".") + 1
+	if octets >= 4 {
+		return ipZero, errInvalidIP
+	}
+	return ipFromString(s + strings.Repeat(".0", 4-octets))
+}
+
+// parsePrefixLen parses the decimal prefix length behind the '/'.
+// Only plain digits are allowed, no sign, no spaces.
+func parsePrefixLen(s string) (int, error) {
+	if s == "" || len(s) > 3 {
+		return 0, errInvalidPrefixLen
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, errInvalidPrefixLen
+		}
+	}
+	return strconv.Atoi(s)
+}
+
+// NewCIDR returns the CIDR block for ip with the given prefix length in bits.
+// Host bits in ip are masked off, NewCIDR(10.0.0.1, 8) returns 10.0.0.0/8.
+//
+// Returns Block{} and error on invalid ip or if bits is out of range for the IP version.
+func NewCIDR(ip IP, bits int) (Block, error) {
+	if !ip.IsValid() {
+		return blockZero, errInvalidIP
+	}
+
+	maxBits := ip.bitLen()
+	if bits < 0 || bits > maxBits {
+		return blockZero, errInvalidPrefixLen
+	}
+
+	mask := setBytes(net.CIDRMask(bits, maxBits))
+
+	a := Block{Mask: mask}
+	a.Base = baseIP(ip, mask)
+	a.Last = lastIP(ip, mask)
+	return a, nil
 }
 
 // lastIP makes last IP address from base IP address and netmask.
@@ -243,6 +411,16 @@ func (a Block) Contains(b Block) bool {
 	return bytes.Compare(a.Base[:], b.Base[:]) <= 0 && bytes.Compare(a.Last[:], b.Last[:]) >= 0
 }
 
+// ContainsIP reports whether the Block a contains the IP address ip.
+// The base and last address of a are part of the block, IP version mismatch
+// or an invalid block or IP returns false.
+func (a Block) ContainsIP(ip IP) bool {
+	if !a.IsValid() || !ip.IsValid() || a.Base[0] != ip[0] {
+		return false
+	}
+	return bytes.Compare(a.Base[:], ip[:]) <= 0 && bytes.Compare(a.Last[:], ip[:]) >= 0
+}
+
 // Compare returns an integer comparing two IP Blocks.
 //
 //   0 if a == b,
@@ -277,6 +455,28 @@ func (a Block) IsCIDR() bool {
 	return a.Mask != ipZero
 }
 
+// PrefixLen returns the prefix length of the CIDR mask in bits, e.g. 24 for 10.0.0.0/24.
+// Returns 0 and false if the block is no CIDR, just a begin-end range.
+func (a Block) PrefixLen() (int, bool) {
+	if !a.IsCIDR() {
+		return 0, false
+	}
+	ones, _ := net.IPMask(a.Mask.Bytes()).Size()
+	return ones, true
+}
+
+// Masked returns the block with base and last address recalculated from the CIDR mask,
+// any host bits in the base address are cleared.
+// Ranges without CIDR mask are returned unchanged.
+func (a Block) Masked() Block {
+	if !a.IsCIDR() {
+		return a
+	}
+	a.Base = baseIP(a.Base, a.Mask)
+	a.Last = lastIP(a.Base, a.Mask)
+	return a
+}
+
 // IsValid returns true on valid Blocks, false otherwise.
 func (a Block) IsValid() bool {
 	if !a.Base.IsValid() || !a.Last.IsValid() {
@@ -318,12 +518,44 @@ func (a Block) BitLen() int {
 
 // Size returns the number of ip addresses as string.
 // Returns a string, since the amount of ip addresses can be greater than uint64.
+// See SizeBig, SizeUint128 and SizeUint64 for numeric results.
 func (a Block) Size() string {
-	// algorithm: use math.big.String(lastIP-baseIP+1)
-	ip := a.Last
-	diff := new(big.Int).SetBytes(ip.SubBytes(a.Base.Bytes()).Bytes())
-	one := new(big.Int).SetInt64(1)
-	return diff.Add(diff, one).String()
+	return a.SizeBig().String()
+}
+
+// SizeBig returns the number of ip addresses as big.Int.
+func (a Block) SizeBig() *big.Int {
+	// algorithm: lastIP-baseIP+1
+	diff := a.Last.toBig()
+	diff.Sub(diff, a.Base.toBig())
+	return diff.Add(diff, big.NewInt(1))
+}
+
+// SizeUint128 returns the number of ip addresses as Uint128.
+// Returns false on overflow, only for ::/0 with 2^128 addresses.
+func (a Block) SizeUint128() (Uint128, bool) {
+	diff, _ := uint128FromIP(a.Last).sub(uint128FromIP(a.Base))
+	return diff.add(Uint128{Lo: 1})
+}
+
+// SizeUint64 returns the number of ip addresses as uint64.
+// Returns false on overflow, e.g. for IPv6 blocks greater than /65.
+func (a Block) SizeUint64() (uint64, bool) {
+	n, ok := a.SizeUint128()
+	if !ok || n.Hi != 0 {
+		return 0, false
+	}
+	return n.Lo, true
+}
+
+// TotalSize returns the number of distinct ip addresses in the blocks,
+// overlapping addresses are counted only once.
+func TotalSize(bs []Block) *big.Int {
+	n := new(big.Int)
+	for _, r := range normalize(bs) {
+		n.Add(n, r.SizeBig())
+	}
+	return n
 }
 
 // IsDisjunctWith reports whether the Blocks a and b are disjunct
@@ -349,6 +581,35 @@ func (a Block) IsDisjunctWith(b Block) bool {
 	return false
 }
 
+// IsAdjacent reports whether the Blocks a and b are adjacent, without gap and without overlap.
+//
+//  a |------|
+//  b        |---|
+//
+//  a        |---|
+//  b |------|
+func (a Block) IsAdjacent(b Block) bool {
+	return isAdjacent(a.Last, b.Base) || isAdjacent(b.Last, a.Base)
+}
+
+// Merge joins the adjacent or overlapping Blocks a and b to one Block,
+// the result is a CIDR if possible, else a range.
+// Returns Block{} and false if there is a gap between a and b.
+func Merge(a, b Block) (Block, bool) {
+	if a.IsDisjunctWith(b) && !a.IsAdjacent(b) {
+		return blockZero, false
+	}
+
+	base, last := a.Base, a.Last
+	if bytes.Compare(b.Base[:], base[:]) < 0 {
+		base = b.Base
+	}
+	if bytes.Compare(b.Last[:], last[:]) > 0 {
+		last = b.Last
+	}
+	return newRange(base, last), true
+}
+
 // OverlapsWith reports whether the Blocks overlaps.
 //
 //  a    |-------|
@@ -383,6 +644,7 @@ func SortBlock(bs []Block) {
 
 // SplitCIDR returns the next 2^n CIDRs, splitted from outer block.
 // The number of CIDRs is limited to MaxCIDRSplit, panics if more CIDRs are requested.
+// See Subnets for a lazy iterator without limit.
 // Returns nil at max mask length or if block is no CIDR.
 func (a Block) SplitCIDR(n int) []Block {
 	// algorithm:
@@ -436,6 +698,41 @@ func (a Block) SplitCIDR(n int) []Block {
 	return cidrs
 }
 
+// Supernet returns the CIDR with the shorter prefix length bits, containing the CIDR a.
+// Supernet(len) with the prefix length of a returns a.
+//
+// Returns Block{} and error if a is no CIDR or bits is greater than the prefix length of a.
+func (a Block) Supernet(bits int) (Block, error) {
+	ones, ok := a.PrefixLen()
+	if !ok {
+		return blockZero, errNoCIDR
+	}
+	if bits < 0 || bits > ones {
+		return blockZero, errInvalidPrefixLen
+	}
+	return NewCIDR(a.Base, bits)
+}
+
+// Sibling returns the other half of the parent CIDR, e.g. 10.0.1.0/24 for 10.0.0.0/24.
+// Returns Block{} and false if a is no CIDR or has prefix length 0.
+func (a Block) Sibling() (Block, bool) {
+	ones, ok := a.PrefixLen()
+	if !ok || ones == 0 {
+		return blockZero, false
+	}
+
+	parent, _ := NewCIDR(a.Base, ones-1)
+
+	// a is the lower half, sibling is the upper half
+	base := parent.Base
+	if base == a.Base {
+		base, _ = a.Last.next()
+	}
+
+	sibling, _ := NewCIDR(base, ones)
+	return sibling, true
+}
+
 // FindFreeCIDR returns all free CIDR blocks (of max possible bitlen) within given CIDR,
 // minus the inner CIDR blocks.
 // Panics if inner blocks are no subset of (or not equal to) outer block.
@@ -580,100 +877,67 @@ func (a Block) BlockToCIDRList() []Block {
 
 		out = append(out, cidr)
 
-		// move the cursor one behind last
-		cursor = last.AddUint64(1)
+		// move the cursor one behind last, stop at the end of the address space
+		next, ok := last.next()
+		if !ok {
+			break
+		}
+		cursor = next
 	}
 
 	return out
 }
 
 // Aggregate returns the minimal number of CIDRs spanning the range of input blocks.
+//
+// The blocks are sorted and swept as ranges, overlapping and adjacent ranges are merged
+// and expanded to CIDRs not until output. The runtime is O(n log n).
+// See also Aggregator for a stream of sorted blocks.
 func Aggregate(bs []Block) []Block {
 	if len(bs) == 0 {
 		return nil
 	}
 
-	// first step: expand input blocks (maybe ranges) to real CIDRs
-	// use a set, get rid of dup cidrs
-	set := map[Block]bool{}
 	for i := range bs {
-		cidrs := bs[i].BlockToCIDRList()
-		for _, cidr := range cidrs {
-			set[cidr] = true
+		if !bs[i].IsValid() {
+			panic(errInvalidBlock)
 		}
 	}
 
-	// next step: maybe we still have supersets and subsets, remove the subsets
-	// back from map to slice
-	cidrs := make([]Block, 0, len(set))
-	for cidr := range set {
-		cidrs = append(cidrs, cidr)
-	}
-
-	// sort slice
-	SortBlock(cidrs)
-
-	// skip subsets
-	unique := make([]Block, 0, len(cidrs))
-	for i := 0; i < len(cidrs); i++ {
-		unique = append(unique, cidrs[i])
+	return cidrList(normalize(bs))
+}
 
-		var cursor int
-		for j := i + 1; j < len(cidrs); j++ {
-			if cidrs[i].Contains(cidrs[j]) {
-				cursor = j
-				continue
-			}
-			break
-		}
+// AggregateRanges returns the minimal number of disjoint ranges spanning the range of input blocks,
+// sorted with IPv4 before IPv6. Blocks are returned as CIDR if the range is a CIDR, see Aggregate.
+func AggregateRanges(bs []Block) []Block {
+	if len(bs) == 0 {
+		return nil
+	}
 
-		// move cursor
-		if cursor != 0 {
-			i = cursor
+	for i := range bs {
+		if !bs[i].IsValid() {
+			panic(errInvalidBlock)
 		}
 	}
 
-	// next step: no more subsets, pack adjacent cidrs to blocks
-	packed := make([]Block, 0, len(unique))
-
-	for i := 0; i < len(unique); i++ {
-		pack := unique[i]
-		var cursor int
-
-		// pack adjacencies to cursor at pos i
-		for j := i + 1; j < len(unique); j++ {
-
-			// break on end of IP address space, else panic on overflow!
-			if pack.Last == ipMaxV4 || pack.Last == ipMaxV6 {
-				break
-			}
-
-			// test for adjacency, no gap between two cidrs
-			if pack.Last.AddUint64(1) == unique[j].Base {
-				// combine adjacent cidrs
-				pack.Last = unique[j].Last
-				cursor = j
-				continue
-			}
-			break
-		}
+	return normalize(bs)
+}
 
-		// we changed pack.Last, calculate new pack.Mask, maybe it's no CIDR, just a range now
-		pack.Mask = pack.getMask()
-		packed = append(packed, pack)
+// AggregateRangesByVersion is like AggregateRanges but returns the IPv4 and IPv6 ranges separated.
+func AggregateRangesByVersion(bs []Block) (v4, v6 []Block) {
+	ranges := AggregateRanges(bs)
 
-		// move cursor
-		if cursor != 0 {
-			i = cursor
-		}
+	// sorted, IPv4 before IPv6
+	i := 0
+	for i < len(ranges) && ranges[i].Base.Version() == 4 {
+		i++
 	}
 
-	// last step: expand packed blocks (maybe ranges) to real CIDRs
-	out := make([]Block, 0, len(packed))
-	for _, r := range packed {
-		cidrList := r.BlockToCIDRList()
-		out = append(out, cidrList...)
+	if i > 0 {
+		v4 = ranges[:i:i]
 	}
-
-	return out
+	if i < len(ranges) {
+		v6 = ranges[i:]
+	}
+	return v4, v6
 }
@@ -28,6 +28,87 @@ func TestString(t *testing.T) {
 	}
 }
 
+// all examples from the ParseBlock doc comment
+func TestParseBlockDocExamples(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"2001:db8:dead:/38", "2001:db8:dc00::/38"},
+		{"10.0.0.0/8", "10.0.0.0/8"},
+		{"10/8", "10.0.0.0/8"},
+		{"192.168/16", "192.168.0.0/16"},
+		{"4.4.4.4", "4.4.4.4/32"},
+		{"2001:db8::1-2001:db8::ff00:35", "2001:db8::1-2001:db8::ff00:35"},
+		{"192.168.2.3-192.168.7.255", "192.168.2.3-192.168.7.255"},
+		{"10.0.0.0/255.0.0.0", "10.0.0.0/8"},
+		{"10.0.0.0 255.0.0.0", "10.0.0.0/8"},
+		{"10.0.0.0 0.255.255.255", "10.0.0.0/8"},
+		{"172.16/12", "172.16.0.0/12"},
+		{"2001:db8:dead:/48", "2001:db8:dead::/48"},
+		{"192.168.0.5/24", "192.168.0.0/24"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBlock(tt.in)
+		if err != nil {
+			t.Errorf("ParseBlock(%q), got error %s", tt.in, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseBlock(%q) = %v, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseBlockAbbrev(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"0/0", "0.0.0.0/0"},
+		{"10/8", "10.0.0.0/8"},
+		{"10/16", "10.0.0.0/16"},
+		{"010/8", "10.0.0.0/8"},
+		{"172.16/12", "172.16.0.0/12"},
+		{"192.168.1/24", "192.168.1.0/24"},
+		{"2001:db8/32", "2001:db8::/32"},
+		{"2001:db8:/32", "2001:db8::/32"},
+		{"2001:db8:dead:/48", "2001:db8:dead::/48"},
+		{"2001:db8:1:2:3:4:5:/112", "2001:db8:1:2:3:4:5:0/112"},
+		{"fe80:/10", "fe80::/10"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBlock(tt.in)
+		if err != nil {
+			t.Errorf("ParseBlock(%q), got error %s", tt.in, err)
+			continue
+		}
+		if got != MustBlock(tt.want) {
+			t.Errorf("ParseBlock(%q) = %v, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{
+		"10",
+		"10/33",
+		"10./8",
+		".10/8",
+		"10..1/8",
+		"256/8",
+		"1.2.3.4.5/8",
+		"2001:db8::dead:/48",
+		"2001:db8:1:2:3:4:5:6:/112",
+		":/0",
+		"2001:dx8:/32",
+	} {
+		if _, err := ParseBlock(in); err == nil {
+			t.Errorf("success for ParseBlock(%q) is not expected!", in)
+		}
+	}
+}
+
 func TestFromStdlib(t *testing.T) {
 	tests := []interface{}{
 		net.IP([]byte{10, 0, 0, 1}),
@@ -51,6 +132,10 @@ func TestParseBlockFail(t *testing.T) {
 		"-10.0.0.0",
 		"/32",
 		"10.0.0.0/33",
+		"10.0.0.0/-1",
+		"10.0.0.0/+8",
+		"10.0.0.0/",
+		"::/129",
 		"127.355.0.1/8",
 		"127.0.0.3-127.0.0.2",
 		"315.0.0.3-127.0.0.2",
@@ -498,6 +583,23 @@ func TestBlockToCIDRListV4(t *testing.T) {
 	}
 }
 
+func TestBlockToCIDRListMax(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want []Block
+	}{
+		{"255.255.255.253-255.255.255.255", blocks("255.255.255.253/32", "255.255.255.254/31")},
+		{"255.255.255.250-255.255.255.255", blocks("255.255.255.250/31", "255.255.255.252/30")},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffd-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+			blocks("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffd/128", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127")},
+	} {
+		got := MustBlock(tt.in).BlockToCIDRList()
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v.BlockToCIDRList(), got %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
 func TestBlockToCIDRListV6(t *testing.T) {
 	b, _ := ParseBlock("2001:db9::1-2001:db9::1234")
 	got := b.BlockToCIDRList()
@@ -529,3 +631,210 @@ func TestBlockToCIDRListV6(t *testing.T) {
 		t.Errorf("%v.BlockToCIDRList(), got %v, want %v", b, got, want)
 	}
 }
+
+func TestNewCIDR(t *testing.T) {
+	tests := []struct {
+		ip   string
+		bits int
+		want string
+	}{
+		{"10.0.0.1", 8, "10.0.0.0/8"},
+		{"10.0.0.1", 32, "10.0.0.1/32"},
+		{"10.0.0.1", 0, "0.0.0.0/0"},
+		{"2001:db8::1", 32, "2001:db8::/32"},
+		{"2001:db8::1", 128, "2001:db8::1/128"},
+		{"2001:db8::1", 0, "::/0"},
+	}
+
+	for _, tt := range tests {
+		got, err := NewCIDR(MustIP(tt.ip), tt.bits)
+		if err != nil {
+			t.Errorf("NewCIDR(%s, %d), got error %s", tt.ip, tt.bits, err)
+			continue
+		}
+		if got != MustBlock(tt.want) {
+			t.Errorf("NewCIDR(%s, %d) = %v, want %s", tt.ip, tt.bits, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		ip   IP
+		bits int
+	}{
+		{MustIP("10.0.0.1"), 33},
+		{MustIP("10.0.0.1"), -1},
+		{MustIP("::1"), 129},
+		{IP{}, 0},
+	} {
+		if _, err := NewCIDR(tt.ip, tt.bits); err == nil {
+			t.Errorf("NewCIDR(%v, %d), expected error", tt.ip, tt.bits)
+		}
+	}
+}
+
+func TestBlockPrefixLen(t *testing.T) {
+	tests := []struct {
+		in   string
+		bits int
+		ok   bool
+	}{
+		{"0.0.0.0/0", 0, true},
+		{"10.0.0.0/8", 8, true},
+		{"10.0.0.1", 32, true},
+		{"10.0.0.1-10.0.0.2", 0, false},
+		{"::/0", 0, true},
+		{"2001:db8::/33", 33, true},
+		{"::1", 128, true},
+	}
+
+	for _, tt := range tests {
+		bits, ok := MustBlock(tt.in).PrefixLen()
+		if bits != tt.bits || ok != tt.ok {
+			t.Errorf("(%s).PrefixLen() = (%d, %v), want (%d, %v)", tt.in, bits, ok, tt.bits, tt.ok)
+		}
+	}
+}
+
+func TestBlockMasked(t *testing.T) {
+	a := MustBlock("10.0.0.0/8")
+	a.Base = MustIP("10.1.2.3")
+	a.Last = MustIP("10.1.2.3")
+
+	if got := a.Masked(); got != MustBlock("10.0.0.0/8") {
+		t.Errorf("Masked() = %v, want 10.0.0.0/8", got)
+	}
+
+	r := MustBlock("10.0.0.1-10.0.0.2")
+	if got := r.Masked(); got != r {
+		t.Errorf("Masked() for range = %v, want %v", got, r)
+	}
+}
+
+func TestBlockContainsIP(t *testing.T) {
+	tests := []struct {
+		block, ip string
+		want      bool
+	}{
+		{"10.0.0.0/8", "10.0.0.0", true},
+		{"10.0.0.0/8", "10.255.255.255", true},
+		{"10.0.0.0/8", "11.0.0.0", false},
+		{"10.0.0.0/8", "9.255.255.255", false},
+		{"10.0.0.3-10.0.0.5", "10.0.0.4", true},
+		{"10.0.0.3-10.0.0.5", "10.0.0.6", false},
+		{"0.0.0.0/0", "::", false},
+		{"::/0", "0.0.0.0", false},
+		{"::/0", "2001:db8::1", true},
+	}
+
+	for _, tt := range tests {
+		got := MustBlock(tt.block).ContainsIP(MustIP(tt.ip))
+		if got != tt.want {
+			t.Errorf("(%s).ContainsIP(%s) = %v, want %v", tt.block, tt.ip, got, tt.want)
+		}
+	}
+
+	// invalid blocks and IPs, no match by raw bytes
+	badIP := MustIP("1.2.3.4")
+	badIP[16] = 1
+	for _, tt := range []struct {
+		block Block
+		ip    IP
+	}{
+		{Block{}, IP{}},
+		{Block{}, MustIP("0.0.0.0")},
+		{MustBlock("0.0.0.0/0"), IP{}},
+		{MustBlock("0.0.0.0/0"), badIP},
+		{MustBlock("::/0"), badIP},
+	} {
+		if tt.block.ContainsIP(tt.ip) {
+			t.Errorf("(%#v).ContainsIP(%#v) = true, want false", tt.block, tt.ip)
+		}
+	}
+}
+
+func TestBlockSupernet(t *testing.T) {
+	tests := []struct {
+		in   string
+		bits int
+		want string
+	}{
+		{"10.1.2.0/24", 8, "10.0.0.0/8"},
+		{"10.1.2.0/24", 24, "10.1.2.0/24"},
+		{"10.1.2.0/24", 0, "0.0.0.0/0"},
+		{"2001:db8:dead:beef::/64", 48, "2001:db8:dead::/48"},
+	}
+
+	for _, tt := range tests {
+		got, err := MustBlock(tt.in).Supernet(tt.bits)
+		if err != nil || got != MustBlock(tt.want) {
+			t.Errorf("(%s).Supernet(%d) = (%v, %v), want %s", tt.in, tt.bits, got, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		in   string
+		bits int
+	}{
+		{"10.1.2.0/24", 25},
+		{"10.1.2.0/24", -1},
+		{"10.0.0.1-10.0.0.2", 8},
+	} {
+		if got, err := MustBlock(tt.in).Supernet(tt.bits); err == nil {
+			t.Errorf("(%s).Supernet(%d) = %v, expected error", tt.in, tt.bits, got)
+		}
+	}
+}
+
+func TestBlockSibling(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"10.0.0.0/24", "10.0.1.0/24", true},
+		{"10.0.1.0/24", "10.0.0.0/24", true},
+		{"0.0.0.0/1", "128.0.0.0/1", true},
+		{"255.255.255.255/32", "255.255.255.254/32", true},
+		{"2001:db8::/33", "2001:db8:8000::/33", true},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/127", true},
+		{"0.0.0.0/0", "", false},
+		{"10.0.0.1-10.0.0.2", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := MustBlock(tt.in).Sibling()
+		if ok != tt.ok || got.String() != tt.want {
+			t.Errorf("(%s).Sibling() = (%v, %v), want (%s, %v)", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBlockIsAdjacentMerge(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		adjacent bool
+		merged   string
+	}{
+		{"10.0.0.0/25", "10.0.0.128/25", true, "10.0.0.0/24"},
+		{"10.0.0.128/25", "10.0.0.0/25", true, "10.0.0.0/24"},
+		{"10.0.0.0/25", "10.0.0.128-10.0.0.200", true, "10.0.0.0-10.0.0.200"},
+		{"10.0.0.128/25", "10.0.1.0/24", true, "10.0.0.128-10.0.1.255"},
+		{"10.0.0.0/24", "10.0.0.128/25", false, "10.0.0.0/24"},
+		{"10.0.0.0-10.0.0.10", "10.0.0.5-10.0.0.16", false, "10.0.0.0-10.0.0.16"},
+		{"10.0.0.0/25", "10.0.0.129/32", false, ""},
+		{"255.255.255.255/32", "::/128", false, ""},
+		{"::/128", "255.255.255.255/32", false, ""},
+	}
+
+	for _, tt := range tests {
+		a, b := MustBlock(tt.a), MustBlock(tt.b)
+		if got := a.IsAdjacent(b); got != tt.adjacent {
+			t.Errorf("(%s).IsAdjacent(%s) = %v, want %v", tt.a, tt.b, got, tt.adjacent)
+		}
+
+		got, ok := Merge(a, b)
+		if ok != (tt.merged != "") || got.String() != tt.merged {
+			t.Errorf("Merge(%s, %s) = (%v, %v), want %s", tt.a, tt.b, got, ok, tt.merged)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package inet
+
+import (
+	"bytes"
+	"net"
+)
+
+// CompactBlock is a memory saving representation of a Block, for huge in-memory tables.
+// A Block has three IPs with 51 bytes, the Mask is redundant for CIDRs and zero for ranges.
+// A CompactBlock has base and last IP and the prefix length in one byte, 35 bytes.
+//
+// The conversion from and to Block is lossless, the sort order is the same, see Compare.
+// The zero value is the compact form of the zero Block.
+type CompactBlock struct {
+	base IP
+	last IP
+	bits uint8 // prefix length + 1, 0 for ranges
+}
+
+// Compact returns the compact representation of the block.
+func (a Block) Compact() CompactBlock {
+	c := CompactBlock{base: a.Base, last: a.Last}
+	if bits, ok := a.PrefixLen(); ok {
+		c.bits = uint8(bits + 1)
+	}
+	return c
+}
+
+// Block returns the Block for the compact representation.
+func (c CompactBlock) Block() Block {
+	a := Block{Base: c.base, Last: c.last}
+	if c.bits != 0 {
+		a.Mask = setBytes(net.CIDRMask(int(c.bits-1), c.base.bitLen()))
+	}
+	return a
+}
+
+// Base returns the base IP address of the block.
+func (c CompactBlock) Base() IP {
+	return c.base
+}
+
+// Last returns the last IP address of the block.
+func (c CompactBlock) Last() IP {
+	return c.last
+}
+
+// PrefixLen returns the prefix length of the CIDR, see Block.PrefixLen.
+// Returns 0 and false if the block is no CIDR, just a begin-end range.
+func (c CompactBlock) PrefixLen() (int, bool) {
+	if c.bits == 0 {
+		return 0, false
+	}
+	return int(c.bits - 1), true
+}
+
+// Compare returns an integer comparing two compact blocks, with the same sort order as Block.Compare.
+func (c CompactBlock) Compare(d CompactBlock) int {
+	if cmp := bytes.Compare(c.base[:], d.base[:]); cmp != 0 {
+		return cmp
+	}
+	// base is now equal, test for superset/subset
+	return bytes.Compare(d.last[:], c.last[:])
+}
+
+// Contains reports whether c contains d, see Block.Contains.
+func (c CompactBlock) Contains(d CompactBlock) bool {
+	if c == d {
+		return false
+	}
+	return bytes.Compare(c.base[:], d.base[:]) <= 0 && bytes.Compare(c.last[:], d.last[:]) >= 0
+}
+
+// String returns the same as Block.String.
+func (c CompactBlock) String() string {
+	return c.Block().String()
+}
@@ -0,0 +1,75 @@
+package inet
+
+import (
+	"math/rand"
+	"testing"
+	"unsafe"
+)
+
+func TestCompactBlock(t *testing.T) {
+	for _, b := range append(blocks(
+		"0.0.0.0/0",
+		"10.0.0.0/8",
+		"10.0.0.1/32",
+		"10.0.0.1-10.0.0.5",
+		"::/0",
+		"2001:db8::/32",
+		"2001:db8::1/128",
+		"2001:db8::1-2001:db8::f",
+	), Block{}) {
+		c := b.Compact()
+		if got := c.Block(); got != b {
+			t.Errorf("%v.Compact().Block() = %#v, want %#v", b, got, b)
+		}
+		if got, want := c.String(), b.String(); got != want {
+			t.Errorf("%v.Compact().String() = %q, want %q", b, got, want)
+		}
+
+		bits, ok := c.PrefixLen()
+		wantBits, wantOK := b.PrefixLen()
+		if bits != wantBits || ok != wantOK {
+			t.Errorf("%v.Compact().PrefixLen() = %d, %v, want %d, %v", b, bits, ok, wantBits, wantOK)
+		}
+
+		if c.Base() != b.Base || c.Last() != b.Last {
+			t.Errorf("%v.Compact(), base and last = %v, %v", b, c.Base(), c.Last())
+		}
+	}
+}
+
+func TestCompactBlockCompare(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	base := MustIP("10.0.0.0")
+
+	randBlock := func() Block {
+		if r.Intn(2) == 0 {
+			cidr, _ := NewCIDR(base.AddUint64(uint64(r.Intn(256))), 24+r.Intn(9))
+			return cidr
+		}
+		lo, hi := uint64(r.Intn(256)), uint64(r.Intn(256))
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		return newRange(base.AddUint64(lo), base.AddUint64(hi))
+	}
+
+	for i := 0; i < 1000; i++ {
+		a, b := randBlock(), randBlock()
+
+		if got, want := a.Compact().Compare(b.Compact()), a.Compare(b); got != want {
+			t.Fatalf("Compact Compare(%v, %v) = %d, want %d", a, b, got, want)
+		}
+		if got, want := a.Compact().Contains(b.Compact()), a.Contains(b); got != want {
+			t.Fatalf("Compact Contains(%v, %v) = %v, want %v", a, b, got, want)
+		}
+	}
+}
+
+func TestCompactBlockSize(t *testing.T) {
+	if got := unsafe.Sizeof(CompactBlock{}); got != 35 {
+		t.Errorf("sizeof CompactBlock = %d, want 35", got)
+	}
+	if got := unsafe.Sizeof(Block{}); got != 51 {
+		t.Errorf("sizeof Block = %d, want 51", got)
+	}
+}
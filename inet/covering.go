@@ -0,0 +1,103 @@
+package inet
+
+import (
+	"bytes"
+	"errors"
+	"math/bits"
+)
+
+var (
+	errEmptyInput      = errors.New("empty input")
+	errVersionMismatch = errors.New("IP version mismatch")
+)
+
+// CoveringCIDR returns the smallest CIDR covering all the blocks, CIDRs and ranges may be mixed,
+// e.g. 10.0.0.0/22 for [10.0.0.17/32 10.0.1.0/24 10.0.2.3-10.0.3.5].
+//
+// Returns Block{} and error on empty input, invalid blocks or IP version mismatch,
+// see CoveringCIDRs for mixed IPv4 and IPv6 input.
+func CoveringCIDR(bs []Block) (Block, error) {
+	if len(bs) == 0 {
+		return blockZero, errEmptyInput
+	}
+
+	base, last := bs[0].Base, bs[0].Last
+	for _, b := range bs {
+		if !b.IsValid() {
+			return blockZero, errInvalidBlock
+		}
+		if b.Base[0] != base[0] {
+			return blockZero, errVersionMismatch
+		}
+
+		if bytes.Compare(b.Base[:], base[:]) < 0 {
+			base = b.Base
+		}
+		if bytes.Compare(b.Last[:], last[:]) > 0 {
+			last = b.Last
+		}
+	}
+
+	return NewCIDR(base, commonPrefixLen(base, last))
+}
+
+// CoveringCIDRForIPs returns the smallest CIDR covering all the IP addresses.
+//
+// Returns Block{} and error on empty input, invalid IPs or IP version mismatch.
+func CoveringCIDRForIPs(ips []IP) (Block, error) {
+	bs := make([]Block, 0, len(ips))
+	for _, ip := range ips {
+		if !ip.IsValid() {
+			return blockZero, errInvalidIP
+		}
+		bs = append(bs, Block{Base: ip, Last: ip, Mask: ip.hostMask()})
+	}
+	return CoveringCIDR(bs)
+}
+
+// CoveringCIDRs returns the smallest covering CIDR per IP version, see CoveringCIDR.
+// The result has zero, one or two CIDRs, the IPv4 CIDR is sorted before the IPv6 CIDR.
+//
+// Returns nil and error on invalid blocks.
+func CoveringCIDRs(bs []Block) ([]Block, error) {
+	var v4, v6 []Block
+	for _, b := range bs {
+		if !b.IsValid() {
+			return nil, errInvalidBlock
+		}
+		if b.Version() == 4 {
+			v4 = append(v4, b)
+		} else {
+			v6 = append(v6, b)
+		}
+	}
+
+	var out []Block
+	for _, family := range [][]Block{v4, v6} {
+		if len(family) == 0 {
+			continue
+		}
+		cidr, err := CoveringCIDR(family)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cidr)
+	}
+	return out, nil
+}
+
+// commonPrefixLen returns the number of leading bits, a and b have in common.
+// a and b must have the same IP version.
+func commonPrefixLen(a, b IP) int {
+	ab, bb := a.Bytes(), b.Bytes()
+
+	n := 0
+	for i := range ab {
+		x := ab[i] ^ bb[i]
+		if x != 0 {
+			return n + bits.LeadingZeros8(x)
+		}
+		n += 8
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+package inet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoveringCIDR(t *testing.T) {
+	tests := []struct {
+		in   []Block
+		want string
+	}{
+		{blocks("10.0.0.17"), "10.0.0.17/32"},
+		{blocks("10.0.0.0/8"), "10.0.0.0/8"},
+		{blocks("10.0.0.17", "10.0.1.0/24", "10.0.2.3-10.0.3.5"), "10.0.0.0/22"},
+		{blocks("10.0.0.255", "10.0.1.0"), "10.0.0.0/23"},
+		{blocks("0.0.0.0", "255.255.255.255"), "0.0.0.0/0"},
+		{blocks("127.0.0.1", "128.0.0.1"), "0.0.0.0/0"},
+		{blocks("2001:db8::1", "2001:db8:ffff::", "2001:db8::/48"), "2001:db8::/32"},
+		{blocks("fe80::1-fe80::3"), "fe80::/126"},
+	}
+
+	for _, tt := range tests {
+		got, err := CoveringCIDR(tt.in)
+		if err != nil || got != MustBlock(tt.want) {
+			t.Errorf("CoveringCIDR(%v) = (%v, %v), want %s", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range [][]Block{
+		nil,
+		blocks("10.0.0.1", "::1"),
+		{MustBlock("10.0.0.1"), {}},
+	} {
+		if got, err := CoveringCIDR(in); err == nil {
+			t.Errorf("CoveringCIDR(%v) = %v, expected error", in, got)
+		}
+	}
+}
+
+func TestCoveringCIDRForIPs(t *testing.T) {
+	ips := []IP{MustIP("192.168.1.7"), MustIP("192.168.1.130"), MustIP("192.168.1.64")}
+	got, err := CoveringCIDRForIPs(ips)
+	if err != nil || got != MustBlock("192.168.1.0/24") {
+		t.Errorf("CoveringCIDRForIPs(%v) = (%v, %v), want 192.168.1.0/24", ips, got, err)
+	}
+
+	if _, err := CoveringCIDRForIPs([]IP{MustIP("192.168.1.7"), MustIP("::1")}); err != errVersionMismatch {
+		t.Errorf("CoveringCIDRForIPs, got %v, want %v", err, errVersionMismatch)
+	}
+	if _, err := CoveringCIDRForIPs([]IP{{}}); err == nil {
+		t.Errorf("CoveringCIDRForIPs(IP{}), expected error")
+	}
+}
+
+func TestCoveringCIDRs(t *testing.T) {
+	got, err := CoveringCIDRs(blocks("2001:db8::1", "10.0.0.1", "10.0.0.6", "2001:db8::8000"))
+	want := blocks("10.0.0.0/29", "2001:db8::/112")
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("CoveringCIDRs, got (%v, %v), want %v", got, err, want)
+	}
+
+	got, err = CoveringCIDRs(nil)
+	if err != nil || got != nil {
+		t.Errorf("CoveringCIDRs(nil), got (%v, %v), want nil", got, err)
+	}
+}
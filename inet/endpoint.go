@@ -0,0 +1,185 @@
+package inet
+
+import (
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+)
+
+var (
+	errInvalidEndpoint  = errors.New("invalid Endpoint")
+	errInvalidPortRange = errors.New("invalid port range")
+)
+
+// Endpoint is an IP address together with a transport port, e.g.
+//
+//  192.0.2.1:80
+//  [2001:db8::1]:443
+//
+// Endpoint is comparable and can be used as key in maps.
+type Endpoint struct {
+	IP   IP
+	Port uint16
+}
+
+// the zero-value for type Endpoint, not public
+var endpointZero Endpoint = Endpoint{}
+
+// ParseEndpoint parses s as IP address and port, IPv6 addresses in square brackets,
+// e.g. "192.0.2.1:80" or "[2001:db8::1]:443".
+// Returns Endpoint{} and error on invalid input.
+func ParseEndpoint(s string) (Endpoint, error) {
+	return endpointFromString(s)
+}
+
+// MustEndpoint is a helper that calls ParseEndpoint and returns just inet.Endpoint or panics on error.
+// It is intended for use in variable initializations.
+func MustEndpoint(s string) Endpoint {
+	ep, err := ParseEndpoint(s)
+	if err != nil {
+		panic(err)
+	}
+	return ep
+}
+
+// endpointFromString splits host and port, see net.SplitHostPort.
+func endpointFromString(s string) (Endpoint, error) {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return endpointZero, errInvalidEndpoint
+	}
+
+	ip, err := ipFromString(host)
+	if err != nil {
+		return endpointZero, errInvalidEndpoint
+	}
+
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return endpointZero, errInvalidEndpoint
+	}
+
+	return Endpoint{IP: ip, Port: uint16(p)}, nil
+}
+
+// IsValid returns true on valid endpoints, false otherwise.
+func (ep Endpoint) IsValid() bool {
+	return ep.IP.IsValid()
+}
+
+// Compare returns an integer comparing two endpoints.
+// The IP addresses are compared first, see IP.Compare, on equal addresses the ports.
+//
+//   0 if a == b
+//  -1 if a < b
+//  +1 if a > b
+func (ep Endpoint) Compare(b Endpoint) int {
+	if c := ep.IP.Compare(b.IP); c != 0 {
+		return c
+	}
+	if ep.Port < b.Port {
+		return -1
+	}
+	if ep.Port > b.Port {
+		return 1
+	}
+	return 0
+}
+
+// SortEndpoint sorts the given slice in place, see Compare() for sort order.
+func SortEndpoint(eps []Endpoint) {
+	sort.Slice(eps, func(i, j int) bool { return eps[i].Compare(eps[j]) == -1 })
+}
+
+// Protocol is the IP protocol number, as assigned by IANA.
+type Protocol uint8
+
+// Protocols with ports. ProtoAny is a wildcard in EndpointRange, matching every protocol.
+const (
+	ProtoAny  Protocol = 0
+	ProtoTCP  Protocol = 6
+	ProtoUDP  Protocol = 17
+	ProtoSCTP Protocol = 132
+)
+
+// String implements the fmt.Stringer interface, e.g. "tcp" or the protocol number.
+func (p Protocol) String() string {
+	switch p {
+	case ProtoAny:
+		return "any"
+	case ProtoTCP:
+		return "tcp"
+	case ProtoUDP:
+		return "udp"
+	case ProtoSCTP:
+		return "sctp"
+	}
+	return strconv.Itoa(int(p))
+}
+
+// EndpointRange is a Block of IP addresses together with a port range and protocol,
+// e.g. the destination of a firewall rule "tcp 10.0.0.0/8 8000-8080".
+type EndpointRange struct {
+	Block     Block
+	FirstPort uint16
+	LastPort  uint16
+	Proto     Protocol
+}
+
+// NewEndpointRange returns the EndpointRange for block, the port range and protocol.
+// Returns error on invalid block or if first > last.
+func NewEndpointRange(block Block, first, last uint16, proto Protocol) (EndpointRange, error) {
+	if !block.IsValid() {
+		return EndpointRange{}, errInvalidBlock
+	}
+	if first > last {
+		return EndpointRange{}, errInvalidPortRange
+	}
+	return EndpointRange{Block: block, FirstPort: first, LastPort: last, Proto: proto}, nil
+}
+
+// String returns r as "proto block port" or "proto block first-last", e.g. "tcp 10.0.0.0/8 8000-8080".
+func (r EndpointRange) String() string {
+	ports := strconv.Itoa(int(r.FirstPort))
+	if r.FirstPort != r.LastPort {
+		ports += "-" + strconv.Itoa(int(r.LastPort))
+	}
+	return r.Proto.String() + " " + r.Block.String() + " " + ports
+}
+
+// ContainsEndpoint reports whether the address and port of ep are in r, the protocol is not checked.
+// Returns false if r has an invalid block, e.g. the zero value.
+func (r EndpointRange) ContainsEndpoint(ep Endpoint) bool {
+	if !r.Block.IsValid() {
+		return false
+	}
+	return r.Block.ContainsIP(ep.IP) && r.FirstPort <= ep.Port && ep.Port <= r.LastPort
+}
+
+// Matches reports whether the protocol, address and port are in r.
+// ProtoAny in r matches every protocol. Returns false if r has an invalid block.
+func (r EndpointRange) Matches(proto Protocol, ep Endpoint) bool {
+	if !r.Block.IsValid() {
+		return false
+	}
+	return (r.Proto == ProtoAny || r.Proto == proto) && r.ContainsEndpoint(ep)
+}
+
+// Contains reports whether o is completely in r: the block, the port range and the protocol.
+// In contrast to Block.Contains, r and o may coincide. ProtoAny in r contains every protocol.
+// Returns false if r or o has an invalid block, e.g. the zero value.
+func (r EndpointRange) Contains(o EndpointRange) bool {
+	if !r.Block.IsValid() || !o.Block.IsValid() {
+		return false
+	}
+	if r.Proto != ProtoAny && r.Proto != o.Proto {
+		return false
+	}
+	if r.FirstPort > o.FirstPort || r.LastPort < o.LastPort {
+		return false
+	}
+
+	rel := Relation(r.Block, o.Block)
+	return rel == RelEqual || rel == RelContains
+}
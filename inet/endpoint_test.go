@@ -0,0 +1,186 @@
+package inet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		in   string
+		ip   string
+		port uint16
+		want string
+	}{
+		{"1.2.3.4:80", "1.2.3.4", 80, "1.2.3.4:80"},
+		{"[2001:db8::1]:443", "2001:db8::1", 443, "[2001:db8::1]:443"},
+		{"[2001:DB8::1]:0", "2001:db8::1", 0, "[2001:db8::1]:0"},
+		{"10.0.0.1:65535", "10.0.0.1", 65535, "10.0.0.1:65535"},
+		{"[::ffff:10.0.0.1]:53", "10.0.0.1", 53, "10.0.0.1:53"},
+	}
+
+	for _, tt := range tests {
+		ep, err := ParseEndpoint(tt.in)
+		if err != nil {
+			t.Errorf("ParseEndpoint(%q), unexpected error: %v", tt.in, err)
+			continue
+		}
+		if ep.IP != MustIP(tt.ip) || ep.Port != tt.port {
+			t.Errorf("ParseEndpoint(%q) = %v, %v, want %v, %v", tt.in, ep.IP, ep.Port, tt.ip, tt.port)
+		}
+		if got := ep.String(); got != tt.want {
+			t.Errorf("ParseEndpoint(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseEndpointFail(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"1.2.3.4",
+		"1.2.3.4:",
+		"1.2.3.4:65536",
+		"1.2.3.4:-1",
+		"1.2.3.4:http",
+		"2001:db8::1:443",
+		"[2001:db8::1]",
+		"[2001:db8::1%eth0]:443",
+		"example.com:80",
+	} {
+		if ep, err := ParseEndpoint(s); err == nil {
+			t.Errorf("ParseEndpoint(%q) = %v, expected error", s, ep)
+		}
+	}
+}
+
+func TestEndpointMarshalUnmarshal(t *testing.T) {
+	for _, s := range []string{"192.168.0.5:22", "[2001:db8::1]:8080", ""} {
+		var ep Endpoint
+		if err := ep.UnmarshalText([]byte(s)); err != nil {
+			t.Errorf("UnmarshalText(%q), got error %s", s, err)
+			continue
+		}
+		text, _ := ep.MarshalText()
+		if string(text) != s {
+			t.Errorf("MarshalText() = %q, want %q", text, s)
+		}
+	}
+}
+
+func TestSortEndpoint(t *testing.T) {
+	sorted := []Endpoint{
+		MustEndpoint("10.0.0.1:22"),
+		MustEndpoint("10.0.0.1:80"),
+		MustEndpoint("10.0.0.2:21"),
+		MustEndpoint("192.168.0.5:443"),
+		MustEndpoint("[::1]:22"),
+		MustEndpoint("[2001:db8::1]:1"),
+	}
+
+	mixed := []Endpoint{sorted[5], sorted[2], sorted[0], sorted[4], sorted[1], sorted[3]}
+	SortEndpoint(mixed)
+
+	if !reflect.DeepEqual(mixed, sorted) {
+		t.Errorf("SortEndpoint, got %v, want %v", mixed, sorted)
+	}
+}
+
+func TestEndpointRange(t *testing.T) {
+	r, err := NewEndpointRange(MustBlock("10.0.0.0/8"), 8000, 8080, ProtoTCP)
+	if err != nil {
+		t.Fatalf("NewEndpointRange(), unexpected error: %v", err)
+	}
+
+	if got, want := r.String(), "tcp 10.0.0.0/8 8000-8080"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		proto    Protocol
+		ep       string
+		contains bool
+		matches  bool
+	}{
+		{ProtoTCP, "10.1.2.3:8000", true, true},
+		{ProtoTCP, "10.1.2.3:8080", true, true},
+		{ProtoUDP, "10.1.2.3:8080", true, false},
+		{ProtoTCP, "10.1.2.3:8081", false, false},
+		{ProtoTCP, "10.1.2.3:7999", false, false},
+		{ProtoTCP, "11.0.0.0:8000", false, false},
+		{ProtoTCP, "[::ffff:a01:203]:8000", true, true},
+		{ProtoTCP, "[::a01:203]:8000", false, false},
+	}
+
+	for _, tt := range tests {
+		ep := MustEndpoint(tt.ep)
+		if got := r.ContainsEndpoint(ep); got != tt.contains {
+			t.Errorf("%v.ContainsEndpoint(%v) = %v, want %v", r, ep, got, tt.contains)
+		}
+		if got := r.Matches(tt.proto, ep); got != tt.matches {
+			t.Errorf("%v.Matches(%v, %v) = %v, want %v", r, tt.proto, ep, got, tt.matches)
+		}
+	}
+
+	all, _ := NewEndpointRange(MustBlock("10.0.0.0/8"), 0, 65535, ProtoAny)
+	if !all.Matches(ProtoUDP, MustEndpoint("10.0.0.1:53")) {
+		t.Errorf("%v.Matches(udp, 10.0.0.1:53) = false, want true", all)
+	}
+
+	if _, err := NewEndpointRange(MustBlock("10.0.0.0/8"), 80, 79, ProtoTCP); err == nil {
+		t.Errorf("NewEndpointRange(80, 79), expected error")
+	}
+	if _, err := NewEndpointRange(Block{}, 80, 80, ProtoTCP); err == nil {
+		t.Errorf("NewEndpointRange(Block{}), expected error")
+	}
+}
+
+func TestEndpointRangeContains(t *testing.T) {
+	r, _ := NewEndpointRange(MustBlock("10.0.0.0/8"), 8000, 8080, ProtoTCP)
+	all, _ := NewEndpointRange(MustBlock("10.0.0.0/8"), 0, 65535, ProtoAny)
+
+	tests := []struct {
+		block       string
+		first, last uint16
+		proto       Protocol
+		want        bool // r contains
+		wantAll     bool // all contains
+	}{
+		{"10.0.0.0/8", 8000, 8080, ProtoTCP, true, true},
+		{"10.1.0.0/16", 8010, 8020, ProtoTCP, true, true},
+		{"10.1.0.0/16", 8010, 8090, ProtoTCP, false, true},
+		{"10.1.0.0/16", 8010, 8020, ProtoUDP, false, true},
+		{"10.1.0.0/16", 8010, 8020, ProtoAny, false, true},
+		{"0.0.0.0/0", 8010, 8020, ProtoTCP, false, false},
+		{"10.255.255.0-11.0.0.1", 8010, 8020, ProtoTCP, false, false},
+	}
+
+	for _, tt := range tests {
+		o, _ := NewEndpointRange(MustBlock(tt.block), tt.first, tt.last, tt.proto)
+		if got := r.Contains(o); got != tt.want {
+			t.Errorf("%v.Contains(%v) = %v, want %v", r, o, got, tt.want)
+		}
+		if got := all.Contains(o); got != tt.wantAll {
+			t.Errorf("%v.Contains(%v) = %v, want %v", all, o, got, tt.wantAll)
+		}
+	}
+
+	// zero value, must not panic
+	var zero EndpointRange
+	if all.Contains(zero) || zero.Contains(all) || zero.Contains(zero) {
+		t.Errorf("Contains with zero EndpointRange, got true, want false")
+	}
+	if zero.ContainsEndpoint(Endpoint{}) || zero.Matches(ProtoAny, Endpoint{}) {
+		t.Errorf("ContainsEndpoint or Matches with zero EndpointRange, got true, want false")
+	}
+	if all.ContainsEndpoint(Endpoint{}) || all.Matches(ProtoAny, Endpoint{}) {
+		t.Errorf("ContainsEndpoint or Matches with zero Endpoint, got true, want false")
+	}
+}
+
+func TestProtocolString(t *testing.T) {
+	for p, want := range map[Protocol]string{ProtoAny: "any", ProtoTCP: "tcp", ProtoUDP: "udp", ProtoSCTP: "sctp", 47: "47"} {
+		if got := p.String(); got != want {
+			t.Errorf("Protocol(%d).String() = %q, want %q", uint8(p), got, want)
+		}
+	}
+}
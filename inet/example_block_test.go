@@ -2,7 +2,10 @@ package inet_test
 
 import (
 	"fmt"
+	"math/big"
 	"net"
+	"os"
+	"strings"
 
 	"github.com/gaissmai/go-inet/inet"
 )
@@ -27,6 +30,22 @@ func ExampleParseBlock() {
 
 }
 
+func ExampleNewCIDR() {
+	for _, ip := range []inet.IP{
+		inet.MustIP("192.168.17.3"),
+		inet.MustIP("2001:db8:dead:beef::1"),
+	} {
+		a, _ := inet.NewCIDR(ip, 20)
+		bits, _ := a.PrefixLen()
+		fmt.Printf("%-22v -> %-20v prefix length: %d\n", ip, a, bits)
+	}
+
+	// Output:
+	// 192.168.17.3           -> 192.168.16.0/20      prefix length: 20
+	// 2001:db8:dead:beef::1  -> 2001::/20            prefix length: 20
+
+}
+
 func ExampleSortBlock() {
 	var buf []inet.Block
 	for _, s := range []string{
@@ -240,3 +259,251 @@ func ExampleAggregate() {
 	// [10.0.0.0/31 10.0.0.4/30 10.0.0.8/29 10.0.0.16/28 10.0.0.32/27 10.0.0.64/27 10.0.0.96/30 fe80::/10]
 
 }
+
+func ExampleDifference() {
+	allowed := []inet.Block{
+		inet.MustBlock("10.0.0.0/8"),
+		inet.MustBlock("192.168.0.0/16"),
+	}
+	blocked := []inet.Block{
+		inet.MustBlock("10.1.0.0/16"),
+		inet.MustBlock("192.168.0.0-192.168.0.9"),
+	}
+
+	ranges := inet.Difference(allowed, blocked)
+	fmt.Println(ranges)
+	fmt.Println(inet.Aggregate(ranges))
+
+	// Output:
+	// [10.0.0.0/16 10.2.0.0-10.255.255.255 192.168.0.10-192.168.255.255]
+	// [10.0.0.0/16 10.2.0.0/15 10.4.0.0/14 10.8.0.0/13 10.16.0.0/12 10.32.0.0/11 10.64.0.0/10 10.128.0.0/9 192.168.0.10/31 192.168.0.12/30 192.168.0.16/28 192.168.0.32/27 192.168.0.64/26 192.168.0.128/25 192.168.1.0/24 192.168.2.0/23 192.168.4.0/22 192.168.8.0/21 192.168.16.0/20 192.168.32.0/19 192.168.64.0/18 192.168.128.0/17]
+
+}
+
+func ExampleIPSetBuilder() {
+	var sb inet.IPSetBuilder
+	sb.AddBlock(inet.MustBlock("10.0.0.0/8"))
+	sb.AddBlock(inet.MustBlock("192.168.0.0/16"))
+	sb.RemoveBlock(inet.MustBlock("10.0.0.0/9"))
+	sb.Add(inet.MustIP("2001:db8::1"))
+
+	allow, _ := sb.IPSet()
+	fmt.Println(allow.Blocks())
+	fmt.Println(allow.ContainsIP(inet.MustIP("10.1.2.3")), allow.ContainsIP(inet.MustIP("10.200.2.3")))
+
+	// Output:
+	// [10.128.0.0/9 192.168.0.0/16 2001:db8::1/128]
+	// false true
+
+}
+
+func ExampleEvalSetExpr() {
+	sets := map[string][]inet.Block{
+		"bogons": {
+			inet.MustBlock("10.0.0.0/8"),
+			inet.MustBlock("172.16.0.0/12"),
+			inet.MustBlock("192.168.0.0/16"),
+		},
+	}
+
+	bs, err := inet.EvalSetExpr("(100.64.0.0/10 + 172.0.0.0/8) & !bogons - 172.31.0.0/16", sets)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(inet.Aggregate(bs))
+
+	_, err = inet.EvalSetExpr("10.0.0.0/8 & !bogus", sets)
+	fmt.Println(err)
+
+	// Output:
+	// [100.64.0.0/10 172.0.0.0/12 172.32.0.0/11 172.64.0.0/10 172.128.0.0/9]
+	// set expression "10.0.0.0/8 & !bogus": pos 14: unknown set "bogus"
+
+}
+
+func ExampleBlock_Iter() {
+	a := inet.MustBlock("192.168.0.0/22")
+
+	it := a.Iter(&inet.IterOptions{Offset: 1, Step: 256})
+	for ip, ok := it.Next(); ok; ip, ok = it.Next() {
+		idx, _ := a.IndexOf(ip)
+		fmt.Printf("%-12v index: %v\n", ip, idx)
+	}
+
+	// Output:
+	// 192.168.0.1  index: 1
+	// 192.168.1.1  index: 257
+	// 192.168.2.1  index: 513
+	// 192.168.3.1  index: 769
+
+}
+
+func ExampleTotalSize() {
+	bs := []inet.Block{
+		inet.MustBlock("10.0.0.0/24"),
+		inet.MustBlock("10.0.0.128/25"), // overlaps, counted once
+		inet.MustBlock("2001:db8::/64"),
+	}
+
+	fmt.Println(inet.TotalSize(bs))
+
+	n, ok := bs[2].SizeUint64()
+	fmt.Println(n, ok)
+
+	// Output:
+	// 18446744073709551872
+	// 0 false
+
+}
+
+func ExampleBlock_Subnet() {
+	site := inet.MustBlock("2001:db8:ab00::/48")
+
+	for _, customerID := range []int64{0, 1, 4711} {
+		prefix, _ := site.Subnet(64, big.NewInt(customerID))
+		idx, _ := site.SubnetIndex(prefix)
+		fmt.Printf("customer %-5d -> %-24v index: %v\n", customerID, prefix, idx)
+	}
+
+	// Output:
+	// customer 0     -> 2001:db8:ab00::/64       index: 0
+	// customer 1     -> 2001:db8:ab00:1::/64     index: 1
+	// customer 4711  -> 2001:db8:ab00:1267::/64  index: 4711
+
+}
+
+func ExampleSplitEven() {
+	bs := []inet.Block{inet.MustBlock("10.0.0.0/30"), inet.MustBlock("10.0.0.128/31")}
+	for _, chunk := range inet.SplitEven(bs, 2) {
+		fmt.Println(chunk)
+	}
+
+	// Output:
+	// [10.0.0.0/31 10.0.0.2/32]
+	// [10.0.0.3/32 10.0.0.128/31]
+}
+
+func ExampleAggregateRanges() {
+	bs := []inet.Block{
+		inet.MustBlock("10.0.0.7-10.0.0.99"),
+		inet.MustBlock("10.0.0.0/30"),
+		inet.MustBlock("2001:db8::/33"),
+		inet.MustBlock("2001:db8:8000::/33"),
+	}
+
+	fmt.Println(inet.AggregateRanges(bs))
+
+	v4, v6 := inet.AggregateRangesByVersion(bs)
+	fmt.Println(v4, v6)
+
+	// Output:
+	// [10.0.0.0/30 10.0.0.7-10.0.0.99 2001:db8::/32]
+	// [10.0.0.0/30 10.0.0.7-10.0.0.99] [2001:db8::/32]
+}
+
+func ExampleSummarize() {
+	bs := []inet.Block{
+		inet.MustBlock("10.0.0.0/25"),
+		inet.MustBlock("10.0.0.192/26"),
+		inet.MustBlock("10.0.1.17"),
+		inet.MustBlock("192.168.0.0/24"),
+	}
+
+	for _, k := range []int{3, 2} {
+		cidrs, over, _ := inet.Summarize(bs, k, nil)
+		fmt.Println(cidrs, over)
+	}
+
+	// must not include the gap 10.0.0.128/26
+	_, _, err := inet.Summarize(bs, 2, []inet.Block{inet.MustBlock("10.0.0.128/26")})
+	fmt.Println(err)
+
+	// Output:
+	// [10.0.0.0/24 10.0.1.17/32 192.168.0.0/24] 64
+	// [10.0.0.0/23 192.168.0.0/24] 319
+	// prefix budget too small
+}
+
+func ExampleBlock_HostRange() {
+	for _, s := range []string{"192.168.1.0/24", "192.168.1.0/31", "2001:db8::/120"} {
+		cidr := inet.MustBlock(s)
+		hosts, _ := cidr.HostRange()
+		fmt.Printf("%-15v %v-%v %v\n", cidr, hosts.Base, hosts.Last, cidr.NumHosts())
+	}
+
+	// Output:
+	// 192.168.1.0/24  192.168.1.1-192.168.1.254 254
+	// 192.168.1.0/31  192.168.1.0-192.168.1.1 2
+	// 2001:db8::/120  2001:db8::1-2001:db8::ff 255
+}
+
+func ExampleBlock_ReverseZones() {
+	for _, s := range []string{"192.0.2.0/24", "10.0.0.0/15", "2001:db8::/31", "192.0.2.64/26"} {
+		fmt.Println(inet.MustBlock(s).ReverseZones())
+	}
+
+	cnames, _ := inet.MustBlock("192.0.2.64/31").ClasslessCNAMEs()
+	for _, rr := range cnames {
+		fmt.Printf("%s. CNAME %s.\n", rr.Name, rr.Target)
+	}
+
+	// Output:
+	// [2.0.192.in-addr.arpa]
+	// [0.10.in-addr.arpa 1.10.in-addr.arpa]
+	// [8.b.d.0.1.0.0.2.ip6.arpa 9.b.d.0.1.0.0.2.ip6.arpa]
+	// [64/26.2.0.192.in-addr.arpa]
+	// 64.2.0.192.in-addr.arpa. CNAME 64.64/31.2.0.192.in-addr.arpa.
+	// 65.2.0.192.in-addr.arpa. CNAME 65.64/31.2.0.192.in-addr.arpa.
+}
+
+func ExampleWritePTRZone() {
+	var forward strings.Builder
+	opts := inet.PTRZoneOptions{
+		Template: "host-{ip}.example.net.",
+		SOA:      "ns1.example.net. hostmaster.example.net. ( 2024010101 3600 900 604800 300 )",
+		NS:       "ns1.example.net.",
+		Forward:  &forward,
+	}
+
+	_ = inet.WritePTRZone(os.Stdout, inet.MustBlock("192.0.2.64/31"), opts)
+	fmt.Print(forward.String())
+
+	// Output:
+	// $ORIGIN 64/31.2.0.192.in-addr.arpa.
+	// $TTL 3600
+	// @	IN	SOA	ns1.example.net. hostmaster.example.net. ( 2024010101 3600 900 604800 300 )
+	// @	IN	NS	ns1.example.net.
+	// 64	IN	PTR	host-192-0-2-64.example.net.
+	// 65	IN	PTR	host-192-0-2-65.example.net.
+	// host-192-0-2-64.example.net.	IN	A	192.0.2.64
+	// host-192-0-2-65.example.net.	IN	A	192.0.2.65
+}
+
+func ExampleRelation() {
+	b := inet.MustBlock("10.0.0.0/24")
+	for _, s := range []string{"10.0.0.0/24", "10.0.0.0/8", "10.0.0.128/25", "10.0.0.200-10.0.1.10", "10.0.1.0/24", "::/0"} {
+		fmt.Printf("%-20s %v\n", s, inet.Relation(inet.MustBlock(s), b))
+	}
+
+	// Output:
+	// 10.0.0.0/24          Equal
+	// 10.0.0.0/8           Contains
+	// 10.0.0.128/25        ContainedBy
+	// 10.0.0.200-10.0.1.10 OverlapsRight
+	// 10.0.1.0/24          AdjacentAfter
+	// ::/0                 VersionMismatch
+}
+
+func ExampleMaskedIP() {
+	m, _ := inet.ParseMaskedIP("10.0.0.1 0.0.3.0")
+
+	fmt.Println(m.Matches(inet.MustIP("10.0.2.1")), m.Matches(inet.MustIP("10.0.4.1")))
+
+	bs, _ := m.Blocks(16)
+	fmt.Println(bs)
+
+	// Output:
+	// true false
+	// [10.0.0.1/32 10.0.1.1/32 10.0.2.1/32 10.0.3.1/32]
+}
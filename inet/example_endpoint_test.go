@@ -0,0 +1,29 @@
+package inet_test
+
+import (
+	"fmt"
+
+	"github.com/gaissmai/go-inet/inet"
+)
+
+func ExampleParseEndpoint() {
+	var eps []inet.Endpoint
+	for _, s := range []string{"[2001:db8::1]:443", "192.0.2.1:80", "192.0.2.1:22"} {
+		ep, _ := inet.ParseEndpoint(s)
+		eps = append(eps, ep)
+	}
+
+	inet.SortEndpoint(eps)
+	fmt.Println(eps)
+
+	rule, _ := inet.NewEndpointRange(inet.MustBlock("192.0.2.0/24"), 1, 1023, inet.ProtoTCP)
+	for _, ep := range eps {
+		fmt.Println(rule, ep, rule.Matches(inet.ProtoTCP, ep))
+	}
+
+	// Output:
+	// [192.0.2.1:22 192.0.2.1:80 [2001:db8::1]:443]
+	// tcp 192.0.2.0/24 1-1023 192.0.2.1:22 true
+	// tcp 192.0.2.0/24 1-1023 192.0.2.1:80 true
+	// tcp 192.0.2.0/24 1-1023 [2001:db8::1]:443 false
+}
@@ -0,0 +1,41 @@
+package inet_test
+
+import (
+	"fmt"
+
+	"github.com/gaissmai/go-inet/inet"
+)
+
+func ExampleParseIfAddr() {
+	for _, s := range []string{
+		"192.168.0.5/24",
+		"2001:db8::cafe/64",
+	} {
+		ifa, _ := inet.ParseIfAddr(s)
+		fmt.Printf("%-20v ip: %-16v block: %v\n", ifa, ifa.IP(), ifa.Block())
+	}
+
+	// Output:
+	// 192.168.0.5/24       ip: 192.168.0.5      block: 192.168.0.0/24
+	// 2001:db8::cafe/64    ip: 2001:db8::cafe   block: 2001:db8::/64
+
+}
+
+func ExampleParseBlockStrict() {
+	for _, s := range []string{
+		"192.168.0.0/24",
+		"192.168.0.5/24",
+	} {
+		a, err := inet.ParseBlockStrict(s)
+		if err != nil {
+			fmt.Println("ERROR:", err)
+			continue
+		}
+		fmt.Println(a)
+	}
+
+	// Output:
+	// 192.168.0.0/24
+	// ERROR: CIDR has host bits set
+
+}
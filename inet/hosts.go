@@ -0,0 +1,71 @@
+package inet
+
+import (
+	"math/big"
+)
+
+// HostRange returns the range of usable host addresses of the CIDR.
+//
+//  IPv4: without network and broadcast address, e.g. 10.0.0.1-10.0.0.254 for 10.0.0.0/24
+//  IPv6: without the subnet-router anycast address, e.g. 2001:db8::1-2001:db8::ff for 2001:db8::/120
+//
+// Point-to-point links (/31 for IPv4, RFC 3021 and /127 for IPv6, RFC 6164) and host routes
+// (/32 and /128) have no reserved addresses, all addresses are usable.
+//
+// Returns false if the block is no CIDR.
+func (a Block) HostRange() (Block, bool) {
+	bits, ok := a.PrefixLen()
+	if !ok {
+		return blockZero, false
+	}
+
+	if a.Base.bitLen()-bits <= 1 {
+		return a, true
+	}
+
+	base, _ := a.Base.next()
+	last := a.Last
+
+	// the IPv4 broadcast address
+	if a.Version() == 4 {
+		last, _ = last.prev()
+	}
+
+	return newRange(base, last), true
+}
+
+// NumHosts returns the number of usable host addresses of the CIDR, see HostRange.
+// Returns 0 if the block is no CIDR.
+func (a Block) NumHosts() *big.Int {
+	hosts, ok := a.HostRange()
+	if !ok {
+		return new(big.Int)
+	}
+	return hosts.SizeBig()
+}
+
+// Broadcast returns the broadcast address of an IPv4 CIDR.
+// Returns false for IPv6, point-to-point links (/31, RFC 3021), host routes and if the block is no CIDR.
+func (a Block) Broadcast() (IP, bool) {
+	bits, ok := a.PrefixLen()
+	if !ok || a.Version() != 4 || bits > 30 {
+		return ipZero, false
+	}
+	return a.Last, true
+}
+
+// SubnetRouterAnycast returns the subnet-router anycast address of an IPv6 CIDR, RFC 4291.
+// Returns false for IPv4, point-to-point links (/127, RFC 6164), host routes and if the block is no CIDR.
+func (a Block) SubnetRouterAnycast() (IP, bool) {
+	bits, ok := a.PrefixLen()
+	if !ok || a.Version() != 6 || bits > 126 {
+		return ipZero, false
+	}
+	return a.Base, true
+}
+
+// IsPointToPoint reports whether the block is a /31 IPv4 CIDR (RFC 3021) or a /127 IPv6 CIDR (RFC 6164).
+func (a Block) IsPointToPoint() bool {
+	bits, ok := a.PrefixLen()
+	return ok && bits == a.Base.bitLen()-1
+}
@@ -0,0 +1,56 @@
+package inet
+
+import (
+	"testing"
+)
+
+func TestBlockHostRange(t *testing.T) {
+	tests := []struct {
+		in        string
+		hosts     string
+		num       string
+		broadcast string
+		anycast   string
+		p2p       bool
+	}{
+		{"10.0.0.0/24", "10.0.0.1-10.0.0.254", "254", "10.0.0.255", "", false},
+		{"10.0.0.0/30", "10.0.0.1-10.0.0.2", "2", "10.0.0.3", "", false},
+		{"10.0.0.0/29", "10.0.0.1-10.0.0.6", "6", "10.0.0.7", "", false},
+		{"10.0.0.0/31", "10.0.0.0/31", "2", "", "", true},
+		{"10.0.0.1/32", "10.0.0.1/32", "1", "", "", false},
+		{"0.0.0.0/0", "0.0.0.1-255.255.255.254", "4294967294", "255.255.255.255", "", false},
+		{"2001:db8::/120", "2001:db8::1-2001:db8::ff", "255", "", "2001:db8::", false},
+		{"2001:db8::/126", "2001:db8::1-2001:db8::3", "3", "", "2001:db8::", false},
+		{"2001:db8::/127", "2001:db8::/127", "2", "", "", true},
+		{"2001:db8::1/128", "2001:db8::1/128", "1", "", "", false},
+		{"::/0", "::1-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "340282366920938463463374607431768211455", "", "::", false},
+		{"10.0.0.1-10.0.0.5", "", "0", "", "", false},
+	}
+
+	for _, tt := range tests {
+		b := MustBlock(tt.in)
+
+		hosts, ok := b.HostRange()
+		if got := hosts.String(); ok != (tt.hosts != "") || ok && got != tt.hosts {
+			t.Errorf("%v.HostRange() = %v, %v, want %v", b, got, ok, tt.hosts)
+		}
+
+		if got := b.NumHosts().String(); got != tt.num {
+			t.Errorf("%v.NumHosts() = %v, want %v", b, got, tt.num)
+		}
+
+		bc, ok := b.Broadcast()
+		if ok != (tt.broadcast != "") || ok && bc.String() != tt.broadcast {
+			t.Errorf("%v.Broadcast() = %v, %v, want %v", b, bc, ok, tt.broadcast)
+		}
+
+		ac, ok := b.SubnetRouterAnycast()
+		if ok != (tt.anycast != "") || ok && ac.String() != tt.anycast {
+			t.Errorf("%v.SubnetRouterAnycast() = %v, %v, want %v", b, ac, ok, tt.anycast)
+		}
+
+		if got := b.IsPointToPoint(); got != tt.p2p {
+			t.Errorf("%v.IsPointToPoint() = %v, want %v", b, got, tt.p2p)
+		}
+	}
+}
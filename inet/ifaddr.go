@@ -0,0 +1,126 @@
+package inet
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+var (
+	errInvalidIfAddr = errors.New("invalid IfAddr")
+	errHostBits      = errors.New("CIDR has host bits set")
+)
+
+// IfAddr is an interface address, an IP address together with the prefix length
+// of the attached network, e.g.
+//
+//  192.168.0.5/24
+//  2001:db8::1/64
+//
+// In contrast to Block the host bits are NOT masked off, the address is kept.
+// IfAddr is comparable and can be used as key in maps.
+type IfAddr struct {
+	Addr IP
+	Bits int
+}
+
+// the zero-value for type IfAddr, not public
+var ifAddrZero IfAddr = IfAddr{}
+
+// NewIfAddr returns the interface address for ip with the given prefix length in bits.
+// Returns IfAddr{} and error on invalid ip or if bits is out of range for the IP version.
+func NewIfAddr(ip IP, bits int) (IfAddr, error) {
+	if !ip.IsValid() {
+		return ifAddrZero, errInvalidIP
+	}
+	if bits < 0 || bits > ip.bitLen() {
+		return ifAddrZero, errInvalidPrefixLen
+	}
+	return IfAddr{Addr: ip, Bits: bits}, nil
+}
+
+// ParseIfAddr parses s as interface address in CIDR notation, e.g. "192.168.0.5/24".
+// Returns IfAddr{} and error on invalid input.
+func ParseIfAddr(s string) (IfAddr, error) {
+	return ifAddrFromString(s)
+}
+
+// MustIfAddr is a helper that calls ParseIfAddr and returns just inet.IfAddr or panics on error.
+// It is intended for use in variable initializations.
+func MustIfAddr(s string) IfAddr {
+	ifa, err := ParseIfAddr(s)
+	if err != nil {
+		panic(err)
+	}
+	return ifa
+}
+
+// ifAddrFromString parses address and prefix length, host bits are kept.
+func ifAddrFromString(s string) (IfAddr, error) {
+	return splitIfAddr(s, ipFromString)
+}
+
+// splitIfAddr splits s at the '/' and parses the address with parseIP and the prefix length.
+func splitIfAddr(s string, parseIP func(string) (IP, error)) (IfAddr, error) {
+	i := strings.IndexByte(s, '/')
+	if i < 0 {
+		return ifAddrZero, errInvalidIfAddr
+	}
+	addr, ones := s[:i], s[i+1:]
+
+	ip, err := parseIP(addr)
+	if err != nil {
+		return ifAddrZero, errInvalidIfAddr
+	}
+
+	bits, err := parsePrefixLen(ones)
+	if err != nil {
+		return ifAddrZero, err
+	}
+
+	return NewIfAddr(ip, bits)
+}
+
+// IP returns the address of the interface, with host bits.
+func (ifa IfAddr) IP() IP {
+	return ifa.Addr
+}
+
+// Block returns the network of the interface address as CIDR, host bits are masked off.
+// Returns Block{} on invalid IfAddr.
+func (ifa IfAddr) Block() Block {
+	b, err := NewCIDR(ifa.Addr, ifa.Bits)
+	if err != nil {
+		return blockZero
+	}
+	return b
+}
+
+// IsValid returns true on valid interface addresses, false otherwise.
+func (ifa IfAddr) IsValid() bool {
+	return ifa.Addr.IsValid() && ifa.Bits >= 0 && ifa.Bits <= ifa.Addr.bitLen()
+}
+
+// Compare returns an integer comparing two interface addresses.
+// The addresses are compared first, on equal addresses the shorter prefix length sorts first.
+//
+//   0 if a == b
+//  -1 if a < b
+//  +1 if a > b
+func (ifa IfAddr) Compare(b IfAddr) int {
+	if c := ifa.Addr.Compare(b.Addr); c != 0 {
+		return c
+	}
+	if ifa.Bits < b.Bits {
+		return -1
+	}
+	if ifa.Bits > b.Bits {
+		return 1
+	}
+	return 0
+}
+
+// SortIfAddr sorts the given slice in place, see Compare() for sort order.
+func SortIfAddr(ifas []IfAddr) {
+	sort.Slice(ifas, func(i, j int) bool { return ifas[i].Compare(ifas[j]) == -1 })
+}
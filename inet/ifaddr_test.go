@@ -0,0 +1,126 @@
+package inet
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestParseIfAddr(t *testing.T) {
+	tests := []struct {
+		in    string
+		ip    string
+		block string
+	}{
+		{"192.168.0.5/24", "192.168.0.5", "192.168.0.0/24"},
+		{"192.168.000.005/24", "192.168.0.5", "192.168.0.0/24"},
+		{"10.0.0.1/32", "10.0.0.1", "10.0.0.1/32"},
+		{"10.0.0.1/0", "10.0.0.1", "0.0.0.0/0"},
+		{"2001:db8::1/64", "2001:db8::1", "2001:db8::/64"},
+		{"fe80::1/10", "fe80::1", "fe80::/10"},
+	}
+
+	for _, tt := range tests {
+		ifa, err := ParseIfAddr(tt.in)
+		if err != nil {
+			t.Errorf("ParseIfAddr(%q), got error %s", tt.in, err)
+			continue
+		}
+		if ifa.IP() != MustIP(tt.ip) {
+			t.Errorf("ParseIfAddr(%q).IP() = %v, want %s", tt.in, ifa.IP(), tt.ip)
+		}
+		if ifa.Block() != MustBlock(tt.block) {
+			t.Errorf("ParseIfAddr(%q).Block() = %v, want %s", tt.in, ifa.Block(), tt.block)
+		}
+	}
+}
+
+func TestParseIfAddrFail(t *testing.T) {
+	tests := []string{
+		"",
+		"192.168.0.5",
+		"192.168.0.5/",
+		"192.168.0.5/33",
+		"192.168.0.5/-1",
+		"/24",
+		"2001:db8::1/129",
+		"2001:dx8::1/64",
+		"10.0.0.0-10.0.0.5",
+	}
+
+	for _, in := range tests {
+		if _, err := ParseIfAddr(in); err == nil {
+			t.Errorf("success for ParseIfAddr(%q) is not expected!", in)
+		}
+	}
+}
+
+func TestIfAddrMarshalUnmarshal(t *testing.T) {
+	for _, s := range []string{"192.168.0.5/24", "2001:db8::1/64", ""} {
+		var ifa IfAddr
+		if err := ifa.UnmarshalText([]byte(s)); err != nil {
+			t.Errorf("UnmarshalText(%q), got error %s", s, err)
+			continue
+		}
+		text, _ := ifa.MarshalText()
+		if string(text) != s {
+			t.Errorf("MarshalText() = %q, want %q", text, s)
+		}
+	}
+}
+
+func TestSortIfAddr(t *testing.T) {
+	sorted := []IfAddr{
+		MustIfAddr("10.0.0.1/8"),
+		MustIfAddr("10.0.0.1/24"),
+		MustIfAddr("10.0.0.2/8"),
+		MustIfAddr("192.168.0.5/24"),
+		MustIfAddr("::1/128"),
+		MustIfAddr("2001:db8::1/64"),
+	}
+
+	mixed := []IfAddr{sorted[5], sorted[2], sorted[0], sorted[4], sorted[1], sorted[3]}
+	SortIfAddr(mixed)
+
+	if !reflect.DeepEqual(mixed, sorted) {
+		t.Errorf("SortIfAddr, got %v, want %v", mixed, sorted)
+	}
+}
+
+func TestParseBlockStrict(t *testing.T) {
+	valid := []interface{}{
+		"192.168.0.0/24",
+		"2001:db8::/32",
+		"10.0.0.1",
+		"10.0.0.3-10.0.0.17",
+		"10.0.0.0/255.0.0.0",
+		"10.0.0.0 255.0.0.0",
+		"10.0.0.0 0.255.255.255",
+		"2001:db8::/ffff:ffff::",
+		net.IPNet{IP: net.IP{10, 0, 0, 0}, Mask: net.CIDRMask(8, 32)},
+	}
+
+	for _, in := range valid {
+		if _, err := ParseBlockStrict(in); err != nil {
+			t.Errorf("ParseBlockStrict(%v), got error %s", in, err)
+		}
+	}
+
+	invalid := []interface{}{
+		"192.168.0.5/24",
+		"2001:db8::1/32",
+		"10.0.0.0/33",
+		"10.0.0.1/255.0.0.0",
+		"10.0.0.1 255.0.0.0",
+		"10.0.0.1 0.255.255.255",
+		"10.0.0.0 255.0.255.0",
+		"2001:db8::1/ffff:ffff::",
+		net.IPNet{IP: net.IP{10, 0, 0, 1}, Mask: net.CIDRMask(8, 32)},
+	}
+
+	for _, in := range invalid {
+		if _, err := ParseBlockStrict(in); err == nil {
+			t.Errorf("success for ParseBlockStrict(%v) is not expected!", in)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package inet
 import (
 	"fmt"
 	"net"
+	"strconv"
 )
 
 // ########################################################
@@ -66,8 +67,7 @@ func (a Block) String() string {
 		return fmt.Sprintf("%s-%s", a.Base, a.Last)
 	}
 
-	mb := a.Mask.Bytes()
-	ones, _ := net.IPMask(mb).Size()
+	ones, _ := a.PrefixLen()
 	return fmt.Sprintf("%s/%d", a.Base, ones)
 }
 
@@ -94,3 +94,87 @@ func (a *Block) UnmarshalText(text []byte) error {
 	*a = x
 	return nil
 }
+
+// ########################################################
+// implementations for type IfAddr
+// ########################################################
+
+// String implements the fmt.Stringer interface, e.g. "192.168.0.5/24".
+// Returns "" on IfAddr{}, panics otherwise on invalid input.
+func (ifa IfAddr) String() string {
+	if ifa == ifAddrZero {
+		return ""
+	}
+
+	if !ifa.IsValid() {
+		panic(errInvalidIfAddr)
+	}
+
+	return fmt.Sprintf("%s/%d", ifa.Addr, ifa.Bits)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The encoding is the same as returned by String.
+func (ifa IfAddr) MarshalText() ([]byte, error) {
+	return []byte(ifa.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The interface address is expected in a form accepted by ParseIfAddr.
+func (ifa *IfAddr) UnmarshalText(text []byte) error {
+	s := string(text)
+	if len(s) == 0 { // this is no error condition
+		*ifa = ifAddrZero
+		return nil
+	}
+
+	x, err := ifAddrFromString(s)
+	if err != nil {
+		return err
+	}
+
+	*ifa = x
+	return nil
+}
+
+// ########################################################
+// implementations for type Endpoint
+// ########################################################
+
+// String implements the fmt.Stringer interface, e.g. "192.0.2.1:80" or "[2001:db8::1]:443".
+// Returns "" on Endpoint{}, panics otherwise on invalid input.
+func (ep Endpoint) String() string {
+	if ep == endpointZero {
+		return ""
+	}
+
+	if !ep.IsValid() {
+		panic(errInvalidEndpoint)
+	}
+
+	return net.JoinHostPort(ep.IP.String(), strconv.Itoa(int(ep.Port)))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The encoding is the same as returned by String.
+func (ep Endpoint) MarshalText() ([]byte, error) {
+	return []byte(ep.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The endpoint is expected in a form accepted by ParseEndpoint.
+func (ep *Endpoint) UnmarshalText(text []byte) error {
+	s := string(text)
+	if len(s) == 0 { // this is no error condition
+		*ep = endpointZero
+		return nil
+	}
+
+	x, err := endpointFromString(s)
+	if err != nil {
+		return err
+	}
+
+	*ep = x
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -35,7 +36,8 @@ var ipZero IP = IP{}
 //   net.IP
 //   []byte
 //
-// The hard part is done by net.ParseIP().
+// IPv4 strings may have leading zeros, at most 3 digits per octet, they are decimal
+// and NOT octal, e.g. "010.0.0.1" is 10.0.0.1. The hard part is done by net.ParseIP().
 // Returns IP{} and error on invalid input.
 func ParseIP(i interface{}) (IP, error) {
 	switch v := i.(type) {
@@ -63,11 +65,33 @@ func MustIP(i interface{}) IP {
 // ipFromString parses s as an IP address, returning the result. The string s can be
 // in dotted decimal ("192.0.2.1") or IPv6 ("2001:db8::42") form. If s is not a
 // valid textual representation of an IP address, ipFromString returns IP{} and error.
-// The real work is done by net.ParseIP() and converted to type IP.
+//
+// IPv4 octets with leading zeros ("010.000.000.001") are decimal, as with go versions
+// before 1.17, but at most 3 digits per octet. The rest is done by net.ParseIP().
 func ipFromString(s string) (IP, error) {
+	if strings.IndexByte(s, '.') >= 0 && strings.IndexByte(s, ':') < 0 {
+		s = trimLeadingZerosV4(s)
+	}
 	return ipFromNetIP(net.ParseIP(s))
 }
 
+// trimLeadingZerosV4 normalizes the decimal octets, "127.000.000.001" -> "127.0.0.1"
+// Octets with more than 3 digits are left untouched, net.ParseIP rejects them.
+func trimLeadingZerosV4(s string) string {
+	octets := strings.Split(s, ".")
+	for i, o := range octets {
+		if len(o) > 3 {
+			return s
+		}
+		t := strings.TrimLeft(o, "0")
+		if t == "" && o != "" {
+			t = "0"
+		}
+		octets[i] = t
+	}
+	return strings.Join(octets, ".")
+}
+
 // ipFromNetIP converts from stdlib net.IP ([]byte) to IP ([17]byte) representation.
 func ipFromNetIP(netIP net.IP) (IP, error) {
 	if netIP == nil {
@@ -120,6 +144,24 @@ func (ip IP) Bytes() []byte {
 	panic(errInvalidIP)
 }
 
+// bitLen returns the number of bits in the address, 32 for IPv4 or 128 for IPv6.
+// Panics on invalid IP.
+func (ip IP) bitLen() int {
+	if ip.Version() == 4 {
+		return 32
+	}
+	return 128
+}
+
+// hostMask returns the /32 or /128 CIDR mask for the IP version.
+// Panics on invalid IP.
+func (ip IP) hostMask() IP {
+	if ip.Version() == 4 {
+		return ipMaxV4
+	}
+	return ipMaxV6
+}
+
 // ToNetIP converts to net.IP. Panics on invalid input.
 func (ip IP) ToNetIP() net.IP {
 	return net.IP(ip.Bytes())
@@ -267,6 +309,99 @@ func reverseIPv6(ip []byte) string {
 	return string(out)
 }
 
+// next returns ip+1, the bool is false on overflow.
+// Much cheaper than AddUint64(1) for stepping through address ranges.
+func (ip IP) next() (IP, bool) {
+	for i := len(ip.Bytes()); i > 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			return ip, true
+		}
+	}
+	return ip, false
+}
+
+// prev returns ip-1, the bool is false on underflow.
+func (ip IP) prev() (IP, bool) {
+	for i := len(ip.Bytes()); i > 0; i-- {
+		ip[i]--
+		if ip[i] != 0xff {
+			return ip, true
+		}
+	}
+	return ip, false
+}
+
+// addUint64 adds n to ip, the bool is false on overflow.
+// Byte arithmetic with carry, no allocations, see AddUint64 for the public API.
+func (ip IP) addUint64(n uint64) (IP, bool) {
+	carry := uint64(0)
+	for i := len(ip.Bytes()); i > 0; i-- {
+		sum := uint64(ip[i]) + n&0xff + carry
+		ip[i] = byte(sum)
+		carry = sum >> 8
+		n >>= 8
+	}
+	return ip, carry == 0 && n == 0
+}
+
+// addPow2 adds 2^n to ip, the bool is false on overflow.
+func (ip IP) addPow2(n int) (IP, bool) {
+	i := len(ip.Bytes()) - n/8
+	if i < 1 {
+		return ip, false
+	}
+
+	sum := uint(ip[i]) + 1<<uint(n%8)
+	ip[i] = byte(sum)
+	if sum>>8 == 0 {
+		return ip, true
+	}
+
+	// carry
+	for i--; i > 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			return ip, true
+		}
+	}
+	return ip, false
+}
+
+// subUint64 subtracts n from ip, the bool is false on underflow.
+func (ip IP) subUint64(n uint64) (IP, bool) {
+	borrow := uint64(0)
+	for i := len(ip.Bytes()); i > 0; i-- {
+		sub := n&0xff + borrow
+		borrow = 0
+		if uint64(ip[i]) < sub {
+			borrow = 1
+		}
+		ip[i] = byte(uint64(ip[i]) + borrow<<8 - sub)
+		n >>= 8
+	}
+	return ip, borrow == 0 && n == 0
+}
+
+// toBig returns the address as big.Int, without the version.
+func (ip IP) toBig() *big.Int {
+	return new(big.Int).SetBytes(ip.Bytes())
+}
+
+// ipFromBig returns the IP with the version of ip and the address x.
+// The bool is false if x doesn't fit in the address space of the version.
+func (ip IP) ipFromBig(x *big.Int) (IP, bool) {
+	n := len(ip.Bytes())
+	if x.Sign() < 0 || x.BitLen() > 8*n {
+		return ipZero, false
+	}
+
+	bs := make([]byte, n)
+	xbs := x.Bytes()
+	copy(bs[n-len(xbs):], xbs)
+	return setBytes(bs), true
+}
+
 // AddUint64 adds i to ip, panics on overflow.
 func (ip IP) AddUint64(i uint64) IP {
 
@@ -23,6 +23,28 @@ func TestPanic(t *testing.T) {
 	MustIP([]byte{1, 2, 3, 4, 5})
 }
 
+func TestParseIPLeadingZeros(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{"010.1.1.1", "10.1.1.1"},
+		{"127.000.000.001", "127.0.0.1"},
+		{"192.168.002.010", "192.168.2.10"},
+	} {
+		ip, err := ParseIP(tt.in)
+		if err != nil || ip.String() != tt.want {
+			t.Errorf("ParseIP(%q) = %v, %v, want %v", tt.in, ip, err, tt.want)
+		}
+	}
+
+	for _, s := range []string{"0010.1.1.1", "00000000000000010.0.0.1", "1.2.3.0004", "256.1.1.1", "1.2.3"} {
+		if ip, err := ParseIP(s); err == nil {
+			t.Errorf("ParseIP(%q) = %v, want error", s, ip)
+		}
+	}
+}
+
 func TestIP_IsValid(t *testing.T) {
 	if ipZero.IsValid() {
 		t.Errorf("ipZero.IsValid() returns true, want false")
@@ -64,3 +86,37 @@ func TestIP_MarshalUnmarshalIPZero(t *testing.T) {
 		t.Errorf("marshal/unmarshal ipZero isn't idempotent")
 	}
 }
+
+func TestIP_NextPrev(t *testing.T) {
+	tests := []struct {
+		ip, next string
+	}{
+		{"10.0.0.255", "10.0.1.0"},
+		{"0.0.0.0", "0.0.0.1"},
+		{"::ffff:ffff", "::1:0:0"},
+		{"2001:db8::", "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		ip, next := MustIP(tt.ip), MustIP(tt.next)
+		if got, ok := ip.next(); !ok || got != next {
+			t.Errorf("%s.next() = (%v, %v), want (%s, true)", tt.ip, got, ok, tt.next)
+		}
+		if got, ok := next.prev(); !ok || got != ip {
+			t.Errorf("%s.prev() = (%v, %v), want (%s, true)", tt.next, got, ok, tt.ip)
+		}
+	}
+
+	if _, ok := ipMaxV4.next(); ok {
+		t.Errorf("ipMaxV4.next() overflow not detected")
+	}
+	if _, ok := ipMaxV6.next(); ok {
+		t.Errorf("ipMaxV6.next() overflow not detected")
+	}
+	if _, ok := MustIP("0.0.0.0").prev(); ok {
+		t.Errorf("0.0.0.0.prev() underflow not detected")
+	}
+	if _, ok := MustIP("::").prev(); ok {
+		t.Errorf("::.prev() underflow not detected")
+	}
+}
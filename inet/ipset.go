@@ -0,0 +1,166 @@
+package inet
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+)
+
+// IPSet is an immutable set of IP addresses, IPv4 and IPv6 may be mixed.
+// Use an IPSetBuilder to create an IPSet.
+//
+// Internally the set is a sorted slice of disjunct and non-adjacent ranges,
+// membership tests are binary searches. An IPSet is safe for concurrent use by multiple goroutines.
+type IPSet struct {
+	ranges []Block
+}
+
+// IPSetBuilder builds an IPSet. The zero value is ready to use.
+// Adds and removes are applied in the order of the calls.
+type IPSetBuilder struct {
+	ranges  []Block // normalized
+	pending []Block // not yet applied adds or removes
+	remove  bool    // pending are removes
+	err     error   // first error on invalid input
+}
+
+// Add adds the ip to the set.
+func (sb *IPSetBuilder) Add(ip IP) {
+	if !ip.IsValid() {
+		sb.setErr(errInvalidIP)
+		return
+	}
+	sb.AddBlock(Block{Base: ip, Last: ip, Mask: ip.hostMask()})
+}
+
+// AddBlock adds all IP addresses of the block to the set.
+func (sb *IPSetBuilder) AddBlock(b Block) {
+	if !b.IsValid() {
+		sb.setErr(errInvalidBlock)
+		return
+	}
+	sb.queue(b, false)
+}
+
+// Remove removes the ip from the set.
+func (sb *IPSetBuilder) Remove(ip IP) {
+	if !ip.IsValid() {
+		sb.setErr(errInvalidIP)
+		return
+	}
+	sb.RemoveBlock(Block{Base: ip, Last: ip, Mask: ip.hostMask()})
+}
+
+// RemoveBlock removes all IP addresses of the block from the set.
+func (sb *IPSetBuilder) RemoveBlock(b Block) {
+	if !b.IsValid() {
+		sb.setErr(errInvalidBlock)
+		return
+	}
+	sb.queue(b, true)
+}
+
+// IPSet returns an immutable snapshot of the current set in the builder.
+// The builder can be used further, without changing the returned IPSet.
+//
+// Returns nil and the first error if any invalid IP or Block was added or removed.
+func (sb *IPSetBuilder) IPSet() (*IPSet, error) {
+	if sb.err != nil {
+		return nil, sb.err
+	}
+	sb.flush()
+
+	ranges := make([]Block, len(sb.ranges))
+	copy(ranges, sb.ranges)
+	return &IPSet{ranges: ranges}, nil
+}
+
+// queue collects adds or removes in a row, they are applied in bulk on change of the operation.
+func (sb *IPSetBuilder) queue(b Block, remove bool) {
+	if remove != sb.remove {
+		sb.flush()
+		sb.remove = remove
+	}
+	sb.pending = append(sb.pending, b)
+}
+
+// flush applies the pending adds or removes.
+func (sb *IPSetBuilder) flush() {
+	if len(sb.pending) == 0 {
+		return
+	}
+	if sb.remove {
+		sb.ranges = Difference(sb.ranges, sb.pending)
+	} else {
+		sb.ranges = Union(sb.ranges, sb.pending)
+	}
+	sb.pending = sb.pending[:0]
+}
+
+func (sb *IPSetBuilder) setErr(err error) {
+	if sb.err == nil {
+		sb.err = err
+	}
+}
+
+// ContainsIP reports whether ip is in the set.
+func (s *IPSet) ContainsIP(ip IP) bool {
+	i := s.search(ip)
+	return i < len(s.ranges) && s.ranges[i].ContainsIP(ip)
+}
+
+// ContainsBlock reports whether all IP addresses of the block are in the set.
+func (s *IPSet) ContainsBlock(b Block) bool {
+	i := s.search(b.Base)
+	return i < len(s.ranges) && s.ranges[i].ContainsIP(b.Base) && s.ranges[i].ContainsIP(b.Last)
+}
+
+// Overlaps reports whether any IP address of the block is in the set.
+func (s *IPSet) Overlaps(b Block) bool {
+	i := s.search(b.Base)
+	return i < len(s.ranges) && !s.ranges[i].IsDisjunctWith(b)
+}
+
+// search returns the index of the first range with range.Last >= ip.
+func (s *IPSet) search(ip IP) int {
+	return sort.Search(len(s.ranges), func(i int) bool { return bytes.Compare(s.ranges[i].Last[:], ip[:]) >= 0 })
+}
+
+// Ranges returns the set as sorted slice of disjunct and non-adjacent ranges.
+// Ranges representable as CIDR have the CIDR mask set.
+func (s *IPSet) Ranges() []Block {
+	out := make([]Block, len(s.ranges))
+	copy(out, s.ranges)
+	return out
+}
+
+// Blocks returns the set as the minimal sorted list of CIDRs, see Aggregate.
+func (s *IPSet) Blocks() []Block {
+	out := make([]Block, 0, len(s.ranges))
+	for _, r := range s.ranges {
+		out = append(out, r.BlockToCIDRList()...)
+	}
+	return out
+}
+
+// Size returns the number of IP addresses in the set.
+func (s *IPSet) Size() *big.Int {
+	n := new(big.Int)
+	for _, r := range s.ranges {
+		n.Add(n, r.SizeBig())
+	}
+	return n
+}
+
+// Equal reports whether s and o contain the same IP addresses.
+func (s *IPSet) Equal(o *IPSet) bool {
+	if len(s.ranges) != len(o.ranges) {
+		return false
+	}
+	for i := range s.ranges {
+		if s.ranges[i] != o.ranges[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package inet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIPSetBuilder(t *testing.T) {
+	var sb IPSetBuilder
+	sb.AddBlock(MustBlock("10.0.0.0/24"))
+	sb.AddBlock(MustBlock("10.0.1.0/24"))
+	sb.Add(MustIP("10.0.2.0"))
+	sb.RemoveBlock(MustBlock("10.0.0.128/25"))
+	sb.Remove(MustIP("10.0.0.0"))
+	sb.AddBlock(MustBlock("2001:db8::/32"))
+	sb.Add(MustIP("10.0.0.0"))
+
+	s, err := sb.IPSet()
+	if err != nil {
+		t.Fatalf("IPSet(), got error %s", err)
+	}
+
+	wantRanges := blocks("10.0.0.0/25", "10.0.1.0-10.0.2.0", "2001:db8::/32")
+	if got := s.Ranges(); !reflect.DeepEqual(got, wantRanges) {
+		t.Errorf("Ranges() = %v, want %v", got, wantRanges)
+	}
+
+	wantBlocks := blocks("10.0.0.0/25", "10.0.1.0/24", "10.0.2.0/32", "2001:db8::/32")
+	if got := s.Blocks(); !reflect.DeepEqual(got, wantBlocks) {
+		t.Errorf("Blocks() = %v, want %v", got, wantBlocks)
+	}
+
+	if got := s.Size().String(); got != "79228162514264337593543950721" {
+		t.Errorf("Size() = %s, want %s", got, "79228162514264337593543950721")
+	}
+
+	// the builder is still usable, the snapshot is immutable
+	sb.RemoveBlock(MustBlock("::/0"))
+	s2, _ := sb.IPSet()
+	if s.Equal(s2) {
+		t.Errorf("IPSet snapshot changed by builder")
+	}
+	if len(s.Ranges()) != 3 || len(s2.Ranges()) != 2 {
+		t.Errorf("unexpected ranges: %v, %v", s.Ranges(), s2.Ranges())
+	}
+}
+
+func TestIPSetBuilderError(t *testing.T) {
+	var sb IPSetBuilder
+	sb.AddBlock(MustBlock("10.0.0.0/8"))
+	sb.Add(IP{})
+
+	if _, err := sb.IPSet(); err == nil {
+		t.Errorf("IPSet() with invalid IP, expected error")
+	}
+
+	sb = IPSetBuilder{}
+	sb.RemoveBlock(Block{})
+	if _, err := sb.IPSet(); err == nil {
+		t.Errorf("IPSet() with invalid Block, expected error")
+	}
+}
+
+func TestIPSetContains(t *testing.T) {
+	var sb IPSetBuilder
+	for _, b := range blocks("10.0.0.0/24", "10.0.2.0/24", "192.168.0.0/16", "2001:db8::/32") {
+		sb.AddBlock(b)
+	}
+	s, _ := sb.IPSet()
+
+	for _, tt := range []struct {
+		ip   string
+		want bool
+	}{
+		{"9.255.255.255", false},
+		{"10.0.0.0", true},
+		{"10.0.0.255", true},
+		{"10.0.1.0", false},
+		{"10.0.2.17", true},
+		{"192.168.255.255", true},
+		{"2001:db8::1", true},
+		{"2001:db9::", false},
+		{"::", false},
+	} {
+		if got := s.ContainsIP(MustIP(tt.ip)); got != tt.want {
+			t.Errorf("ContainsIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		block              string
+		contains, overlaps bool
+	}{
+		{"10.0.0.0/24", true, true},
+		{"10.0.0.0/25", true, true},
+		{"10.0.0.0/23", false, true},
+		{"10.0.1.0/24", false, false},
+		{"10.0.1.255-10.0.2.0", false, true},
+		{"0.0.0.0/0", false, true},
+		{"2001:db8:1::/48", true, true},
+		{"fe80::/10", false, false},
+	} {
+		b := MustBlock(tt.block)
+		if got := s.ContainsBlock(b); got != tt.contains {
+			t.Errorf("ContainsBlock(%s) = %v, want %v", tt.block, got, tt.contains)
+		}
+		if got := s.Overlaps(b); got != tt.overlaps {
+			t.Errorf("Overlaps(%s) = %v, want %v", tt.block, got, tt.overlaps)
+		}
+	}
+}
+
+func TestIPSetEqual(t *testing.T) {
+	var a, b IPSetBuilder
+	a.AddBlock(MustBlock("10.0.0.0/24"))
+	b.AddBlock(MustBlock("10.0.0.128/25"))
+	b.AddBlock(MustBlock("10.0.0.0-10.0.0.127"))
+
+	sa, _ := a.IPSet()
+	sb, _ := b.IPSet()
+	if !sa.Equal(sb) {
+		t.Errorf("Equal(%v, %v) = false, want true", sa.Ranges(), sb.Ranges())
+	}
+
+	b.Remove(MustIP("10.0.0.7"))
+	sb, _ = b.IPSet()
+	if sa.Equal(sb) {
+		t.Errorf("Equal(%v, %v) = true, want false", sa.Ranges(), sb.Ranges())
+	}
+}
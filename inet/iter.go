@@ -0,0 +1,111 @@
+package inet
+
+import (
+	"bytes"
+	"math/big"
+)
+
+// IterOptions controls the iteration over the addresses of a Block, the zero value
+// iterates over every address from base to last.
+type IterOptions struct {
+	Offset  uint64 // skip the first Offset addresses, counted in iteration direction
+	Step    uint64 // stride between the addresses, 0 is the same as 1
+	Reverse bool   // iterate from last to base
+}
+
+// BlockIter iterates lazily over the IP addresses of a Block, CIDRs and ranges.
+// No addresses are materialized, even huge IPv6 blocks can be iterated.
+//
+//  it := block.Iter(nil)
+//  for ip, ok := it.Next(); ok; ip, ok = it.Next() {
+//    ...
+//  }
+type BlockIter struct {
+	block   Block
+	next    IP
+	step    uint64
+	reverse bool
+	done    bool
+}
+
+// Iter returns an iterator over the IP addresses of the block, opts may be nil.
+// Panics on invalid block.
+func (a Block) Iter(opts *IterOptions) *BlockIter {
+	if !a.IsValid() {
+		panic(errInvalidBlock)
+	}
+
+	var o IterOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Step == 0 {
+		o.Step = 1
+	}
+
+	it := &BlockIter{block: a, step: o.Step, reverse: o.Reverse}
+
+	var ok bool
+	if o.Reverse {
+		it.next, ok = a.Last.subUint64(o.Offset)
+	} else {
+		it.next, ok = a.Base.addUint64(o.Offset)
+	}
+
+	// offset out of block
+	if !ok || !a.ContainsIP(it.next) {
+		it.done = true
+	}
+
+	return it
+}
+
+// Next returns the next IP address and true, or IP{} and false if the iteration is exhausted.
+func (it *BlockIter) Next() (IP, bool) {
+	if it.done {
+		return ipZero, false
+	}
+
+	ip := it.next
+
+	var ok bool
+	if it.reverse {
+		it.next, ok = ip.subUint64(it.step)
+		ok = ok && bytes.Compare(it.next[:], it.block.Base[:]) >= 0
+	} else {
+		it.next, ok = ip.addUint64(it.step)
+		ok = ok && bytes.Compare(it.next[:], it.block.Last[:]) <= 0
+	}
+
+	if !ok {
+		it.done = true
+	}
+
+	return ip, true
+}
+
+// Nth returns the address at index i in the block, Nth(0) is the base address.
+// Returns IP{} and false if i is out of range.
+func (a Block) Nth(i *big.Int) (IP, bool) {
+	if i.Sign() < 0 {
+		return ipZero, false
+	}
+
+	x := a.Base.toBig()
+	ip, ok := a.Base.ipFromBig(x.Add(x, i))
+	if !ok || !a.ContainsIP(ip) {
+		return ipZero, false
+	}
+	return ip, true
+}
+
+// IndexOf returns the index of ip in the block, the inverse of Nth.
+// Returns nil and false if ip isn't in the block.
+func (a Block) IndexOf(ip IP) (*big.Int, bool) {
+	if !a.ContainsIP(ip) {
+		return nil, false
+	}
+
+	x := ip.toBig()
+	return x.Sub(x, a.Base.toBig()), true
+}
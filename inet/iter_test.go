@@ -0,0 +1,152 @@
+package inet
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func collect(it *BlockIter) []string {
+	var out []string
+	for ip, ok := it.Next(); ok; ip, ok = it.Next() {
+		out = append(out, ip.String())
+	}
+	return out
+}
+
+func TestBlockIter(t *testing.T) {
+	tests := []struct {
+		block string
+		opts  *IterOptions
+		want  []string
+	}{
+		{"10.0.0.0/30", nil, []string{"10.0.0.0", "10.0.0.1", "10.0.0.2", "10.0.0.3"}},
+		{"10.0.0.254-10.0.1.1", nil, []string{"10.0.0.254", "10.0.0.255", "10.0.1.0", "10.0.1.1"}},
+		{"10.0.0.0/30", &IterOptions{Reverse: true}, []string{"10.0.0.3", "10.0.0.2", "10.0.0.1", "10.0.0.0"}},
+		{"10.0.0.0/29", &IterOptions{Step: 3}, []string{"10.0.0.0", "10.0.0.3", "10.0.0.6"}},
+		{"10.0.0.0/29", &IterOptions{Step: 3, Offset: 1}, []string{"10.0.0.1", "10.0.0.4", "10.0.0.7"}},
+		{"10.0.0.0/29", &IterOptions{Step: 3, Reverse: true}, []string{"10.0.0.7", "10.0.0.4", "10.0.0.1"}},
+		{"10.0.0.0/29", &IterOptions{Offset: 6, Reverse: true}, []string{"10.0.0.1", "10.0.0.0"}},
+		{"10.0.0.0/29", &IterOptions{Offset: 8}, nil},
+		{"10.0.0.0/29", &IterOptions{Offset: 8, Reverse: true}, nil},
+		{"10.0.0.0/29", &IterOptions{Step: 100}, []string{"10.0.0.0"}},
+		{"255.255.255.254/31", nil, []string{"255.255.255.254", "255.255.255.255"}},
+		{"0.0.0.0/31", &IterOptions{Reverse: true}, []string{"0.0.0.1", "0.0.0.0"}},
+		{"::/0", &IterOptions{Offset: 1<<64 - 1, Step: 1 << 63}, []string{"::ffff:ffff:ffff:ffff", "::1:7fff:ffff:ffff:ffff", "::1:ffff:ffff:ffff:ffff", "::2:7fff:ffff:ffff:ffff", "::2:ffff:ffff:ffff:ffff"}},
+		{"2001:db8::fffe-2001:db8::1:1", nil, []string{"2001:db8::fffe", "2001:db8::ffff", "2001:db8::1:0", "2001:db8::1:1"}},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127", nil, []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"}},
+	}
+
+	for _, tt := range tests {
+		it := MustBlock(tt.block).Iter(tt.opts)
+
+		// stop after 5 addresses, ::/0 is huge
+		var got []string
+		for ip, ok := it.Next(); ok; ip, ok = it.Next() {
+			got = append(got, ip.String())
+			if len(got) == 5 {
+				break
+			}
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("(%s).Iter(%+v), got %v, want %v", tt.block, tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestBlockIterExhausted(t *testing.T) {
+	it := MustBlock("10.0.0.0/31").Iter(nil)
+	if got := collect(it); len(got) != 2 {
+		t.Errorf("Iter, got %v, want 2 addresses", got)
+	}
+	if ip, ok := it.Next(); ok || ip != ipZero {
+		t.Errorf("Next() after end, got (%v, %v)", ip, ok)
+	}
+}
+
+func TestBlockNthIndexOf(t *testing.T) {
+	tests := []struct {
+		block string
+		i     int64
+		ip    string
+	}{
+		{"10.0.0.0/8", 0, "10.0.0.0"},
+		{"10.0.0.0/8", 256, "10.0.1.0"},
+		{"10.0.0.0/8", 1<<24 - 1, "10.255.255.255"},
+		{"10.0.0.3-10.0.0.17", 14, "10.0.0.17"},
+		{"2001:db8::/32", 1 << 40, "2001:db8::100:0:0"},
+	}
+
+	for _, tt := range tests {
+		a := MustBlock(tt.block)
+		ip, ok := a.Nth(big.NewInt(tt.i))
+		if !ok || ip != MustIP(tt.ip) {
+			t.Errorf("(%s).Nth(%d) = (%v, %v), want (%s, true)", tt.block, tt.i, ip, ok, tt.ip)
+		}
+
+		i, ok := a.IndexOf(MustIP(tt.ip))
+		if !ok || i.Int64() != tt.i {
+			t.Errorf("(%s).IndexOf(%s) = (%v, %v), want (%d, true)", tt.block, tt.ip, i, ok, tt.i)
+		}
+	}
+
+	a := MustBlock("10.0.0.3-10.0.0.17")
+	for _, i := range []int64{-1, 15, 1 << 40} {
+		if ip, ok := a.Nth(big.NewInt(i)); ok {
+			t.Errorf("(%s).Nth(%d) = %v, want false", a, i, ip)
+		}
+	}
+	for _, s := range []string{"10.0.0.2", "10.0.0.18", "::a00:3"} {
+		if i, ok := a.IndexOf(MustIP(s)); ok {
+			t.Errorf("(%s).IndexOf(%s) = %v, want false", a, s, i)
+		}
+	}
+
+	// beyond uint64
+	v6 := MustBlock("::/0")
+	huge := new(big.Int).Lsh(big.NewInt(1), 127)
+	if ip, ok := v6.Nth(huge); !ok || ip != MustIP("8000::") {
+		t.Errorf("(::/0).Nth(2^127) = (%v, %v), want (8000::, true)", ip, ok)
+	}
+	if i, ok := v6.IndexOf(MustIP("8000::")); !ok || i.Cmp(huge) != 0 {
+		t.Errorf("(::/0).IndexOf(8000::) = (%v, %v), want (2^127, true)", i, ok)
+	}
+}
+
+func TestIPAddSubUint64(t *testing.T) {
+	tests := []struct {
+		ip   string
+		n    uint64
+		want string
+	}{
+		{"10.0.0.255", 1, "10.0.1.0"},
+		{"10.0.0.0", 1 << 24, "11.0.0.0"},
+		{"0.0.0.0", 1<<32 - 1, "255.255.255.255"},
+		{"::", 1<<64 - 1, "::ffff:ffff:ffff:ffff"},
+		{"::ffff:ffff:ffff:ffff", 1, "0:0:0:1::"},
+	}
+
+	for _, tt := range tests {
+		ip, want := MustIP(tt.ip), MustIP(tt.want)
+		if got, ok := ip.addUint64(tt.n); !ok || got != want {
+			t.Errorf("%s.addUint64(%d) = (%v, %v), want %s", tt.ip, tt.n, got, ok, tt.want)
+		}
+		if got, ok := want.subUint64(tt.n); !ok || got != ip {
+			t.Errorf("%s.subUint64(%d) = (%v, %v), want %s", tt.want, tt.n, got, ok, tt.ip)
+		}
+	}
+
+	if _, ok := MustIP("255.255.255.255").addUint64(1); ok {
+		t.Errorf("addUint64, overflow not detected")
+	}
+	if _, ok := MustIP("0.0.0.1").addUint64(1 << 32); ok {
+		t.Errorf("addUint64, overflow not detected")
+	}
+	if _, ok := MustIP("0.0.0.1").subUint64(2); ok {
+		t.Errorf("subUint64, underflow not detected")
+	}
+	if _, ok := MustIP("255.255.255.255").subUint64(1 << 32); ok {
+		t.Errorf("subUint64, underflow not detected")
+	}
+}
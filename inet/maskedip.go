@@ -0,0 +1,151 @@
+package inet
+
+import (
+	"errors"
+	"math/bits"
+	"strings"
+)
+
+var errTooManyBlocks = errors.New("expansion exceeds limit")
+
+// MaskedIP is an IP address with an arbitrary, maybe non-contiguous, wildcard mask
+// as used in ACLs, e.g. "10.0.0.1 0.0.255.0" matches the host .1 in the 256 subnets 10.0.0.0/24 ... 10.0.255.0/24.
+// The set bits in Wildcard are don't-care bits, they are cleared in Addr.
+//
+// Contiguous wildcard masks are CIDRs, see ParseBlockWithWildcard.
+type MaskedIP struct {
+	Addr     IP
+	Wildcard IP
+}
+
+// NewMaskedIP returns the MaskedIP for addr and the wildcard mask, the don't-care bits in addr are cleared.
+// Returns error on invalid input or IP version mismatch.
+func NewMaskedIP(addr, wildcard IP) (MaskedIP, error) {
+	if !addr.IsValid() {
+		return MaskedIP{}, errInvalidIP
+	}
+	if !wildcard.IsValid() || wildcard.Version() != addr.Version() {
+		return MaskedIP{}, errInvalidMask
+	}
+
+	for i := 1; i <= len(addr.Bytes()); i++ {
+		addr[i] &^= wildcard[i]
+	}
+	return MaskedIP{Addr: addr, Wildcard: wildcard}, nil
+}
+
+// ParseMaskedIP parses s in "addr wildcard" notation, separated by white space, e.g. "10.0.0.1 0.0.255.0".
+func ParseMaskedIP(s string) (MaskedIP, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return MaskedIP{}, errInvalidMask
+	}
+
+	addr, err := ipFromString(fields[0])
+	if err != nil {
+		return MaskedIP{}, errInvalidIP
+	}
+
+	wildcard, err := ipFromString(fields[1])
+	if err != nil {
+		return MaskedIP{}, errInvalidMask
+	}
+
+	return NewMaskedIP(addr, wildcard)
+}
+
+// String returns m in "addr wildcard" notation.
+func (m MaskedIP) String() string {
+	if m == (MaskedIP{}) {
+		return ""
+	}
+	return m.Addr.String() + " " + m.Wildcard.String()
+}
+
+// isValid reports whether addr and wildcard are valid and of the same IP version.
+func (m MaskedIP) isValid() bool {
+	return m.Addr.IsValid() && m.Wildcard.IsValid() && m.Wildcard[0] == m.Addr[0]
+}
+
+// Matches reports whether ip matches m, all bits not in the wildcard mask are equal.
+// IP version mismatch or an invalid m returns false.
+func (m MaskedIP) Matches(ip IP) bool {
+	if !m.isValid() || ip[0] != m.Addr[0] {
+		return false
+	}
+	for i := 1; i <= len(ip.Bytes()); i++ {
+		if (ip[i]^m.Addr[i])&^m.Wildcard[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersects reports whether any IP matches m and o.
+// IP version mismatch or an invalid m or o returns false.
+func (m MaskedIP) Intersects(o MaskedIP) bool {
+	if !m.isValid() || !o.isValid() || m.Addr[0] != o.Addr[0] {
+		return false
+	}
+	// the bits relevant for both must be equal
+	for i := 1; i <= len(m.Addr.Bytes()); i++ {
+		if (m.Addr[i]^o.Addr[i])&^m.Wildcard[i]&^o.Wildcard[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Blocks returns the sorted, minimal list of CIDRs matching m.
+// The trailing ones in the wildcard mask are the host bits of the CIDRs,
+// every other wildcard bit doubles the number of CIDRs.
+//
+// Returns error on invalid m or if more than limit CIDRs would be needed.
+func (m MaskedIP) Blocks(limit int) ([]Block, error) {
+	if !m.Addr.IsValid() {
+		return nil, errInvalidIP
+	}
+	if !m.isValid() {
+		return nil, errInvalidMask
+	}
+
+	wc := m.Wildcard.Bytes()
+	n := len(wc)
+
+	// bit positions of the wildcard bits, counted from LSB; trailing ones are host bits
+	var free []int
+	hostBits := 0
+	trailing := true
+	for pos := 0; pos < n*8; pos++ {
+		set := wc[n-1-pos/8]>>uint(pos%8)&1 == 1
+		switch {
+		case set && trailing:
+			hostBits++
+		case set:
+			free = append(free, pos)
+		default:
+			trailing = false
+		}
+	}
+
+	if len(free) >= bits.UintSize-1 || 1<<uint(len(free)) > limit {
+		return nil, errTooManyBlocks
+	}
+
+	out := make([]Block, 0, 1<<uint(len(free)))
+	for i := 0; i < 1<<uint(len(free)); i++ {
+		ip := m.Addr
+		for k, pos := range free {
+			if i>>uint(k)&1 == 1 {
+				ip[n-pos/8] |= 1 << uint(pos%8)
+			}
+		}
+
+		cidr, err := NewCIDR(ip, n*8-hostBits)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cidr)
+	}
+	return out, nil
+}
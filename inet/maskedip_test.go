@@ -0,0 +1,167 @@
+package inet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMaskedIP(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "10.0.0.1 0.0.255.0", want: "10.0.0.1 0.0.255.0"},
+		{in: "10.0.7.1  0.0.255.0", want: "10.0.0.1 0.0.255.0"},
+		{in: "10.0.0.0 0.0.0.255", want: "10.0.0.0 0.0.0.255"},
+		{in: "2001:db8::1 ::ffff:0:0:0", want: "2001:db8::1 ::ffff:0:0:0"},
+		{in: "10.0.0.1", wantErr: true},
+		{in: "10.0.0.1 ::ff", wantErr: true},
+		{in: "10.0.0.1 0.0.256.0", wantErr: true},
+		{in: "10.0.0.x 0.0.255.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		m, err := ParseMaskedIP(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseMaskedIP(%q), expected error, got %v", tt.in, m)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMaskedIP(%q), unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got := m.String(); got != tt.want {
+			t.Errorf("ParseMaskedIP(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMaskedIPMatches(t *testing.T) {
+	m, _ := ParseMaskedIP("10.0.0.1 0.0.255.0")
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"10.0.0.1", true},
+		{"10.0.17.1", true},
+		{"10.0.255.1", true},
+		{"10.0.0.2", false},
+		{"10.1.0.1", false},
+		{"::ffff:10.0.3.1", true},
+		{"::a00:1", false},
+	}
+
+	for _, tt := range tests {
+		if got := m.Matches(MustIP(tt.ip)); got != tt.want {
+			t.Errorf("%v.Matches(%v) = %v, want %v", m, tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestMaskedIPIntersects(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"10.0.0.1 0.0.255.0", "10.0.0.1 0.0.255.0", true},
+		{"10.0.0.1 0.0.255.0", "10.0.5.0 0.0.0.255", true},
+		{"10.0.0.1 0.0.255.0", "10.0.5.2 0.0.0.0", false},
+		{"10.0.0.1 0.0.255.0", "10.0.0.0 0.0.255.254", false},
+		{"10.0.0.1 0.0.255.0", "10.0.0.0 0.0.255.255", true},
+		{"10.0.0.1 0.0.255.0", "11.0.0.1 0.0.255.0", false},
+		{"0.0.0.0 255.255.255.255", "::1 ::", false},
+	}
+
+	for _, tt := range tests {
+		a, _ := ParseMaskedIP(tt.a)
+		b, _ := ParseMaskedIP(tt.b)
+		if got := a.Intersects(b); got != tt.want {
+			t.Errorf("%v.Intersects(%v) = %v, want %v", a, b, got, tt.want)
+		}
+		if got := b.Intersects(a); got != tt.want {
+			t.Errorf("%v.Intersects(%v) = %v, want %v", b, a, got, tt.want)
+		}
+	}
+}
+
+func TestMaskedIPBlocks(t *testing.T) {
+	tests := []struct {
+		in      string
+		limit   int
+		want    []Block
+		wantErr bool
+	}{
+		{in: "10.0.0.0 0.0.0.255", limit: 1, want: blocks("10.0.0.0/24")},
+		{in: "10.0.0.1 0.0.0.0", limit: 1, want: blocks("10.0.0.1/32")},
+		{in: "10.0.0.1 0.0.3.0", limit: 4, want: blocks("10.0.0.1/32", "10.0.1.1/32", "10.0.2.1/32", "10.0.3.1/32")},
+		{in: "10.0.0.0 0.0.2.3", limit: 2, want: blocks("10.0.0.0/30", "10.0.2.0/30")},
+		{in: "10.0.0.1 0.0.255.0", limit: 255, wantErr: true},
+		{in: "10.0.0.1 255.255.255.0", limit: 1 << 20, wantErr: true},
+		{in: "2001:db8::1 0:0:0:1::1", limit: 2, want: blocks("2001:db8::/127", "2001:db8:0:1::/127")},
+		{in: "::1 ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe", limit: 1 << 30, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		m, _ := ParseMaskedIP(tt.in)
+		got, err := m.Blocks(tt.limit)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%v.Blocks(%d), expected error, got %v", m, tt.limit, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v.Blocks(%d), unexpected error: %v", m, tt.limit, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v.Blocks(%d) = %v, want %v", m, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestMaskedIPBlocksMatch(t *testing.T) {
+	// every address matched by the blocks is matched by the MaskedIP and vice versa
+	m, _ := ParseMaskedIP("10.0.0.5 0.0.0.170")
+	bs, err := m.Blocks(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := MustIP("10.0.0.0")
+	for i := uint64(0); i < 256; i++ {
+		ip := base.AddUint64(i)
+		if got, want := containsIP(bs, ip), m.Matches(ip); got != want {
+			t.Errorf("%v: Blocks %v match %v, Matches %v", ip, bs, got, want)
+		}
+	}
+}
+
+func TestMaskedIPInvalid(t *testing.T) {
+	valid, _ := ParseMaskedIP("10.0.0.1 0.0.255.0")
+
+	for _, m := range []MaskedIP{
+		{},
+		{Addr: MustIP("10.0.0.1")},
+		{Addr: MustIP("10.0.0.1"), Wildcard: MustIP("::ff")},
+		{Wildcard: MustIP("0.0.0.255")},
+	} {
+		if bs, err := m.Blocks(256); err == nil {
+			t.Errorf("%#v.Blocks(256) = %v, expected error", m, bs)
+		}
+		if m.Matches(MustIP("10.0.0.1")) {
+			t.Errorf("%#v.Matches(10.0.0.1) = true, want false", m)
+		}
+		if m.Intersects(valid) || valid.Intersects(m) {
+			t.Errorf("%#v.Intersects(%v) = true, want false", m, valid)
+		}
+	}
+
+	if _, err := (MaskedIP{Addr: MustIP("10.0.0.1")}).Blocks(256); err != errInvalidMask {
+		t.Errorf("Blocks() with zero wildcard, got error %v, want %v", err, errInvalidMask)
+	}
+}
@@ -0,0 +1,161 @@
+package inet
+
+import (
+	"errors"
+	"math/bits"
+	"strings"
+)
+
+var (
+	errInvalidMask       = errors.New("invalid mask")
+	errNonContiguousMask = errors.New("non-contiguous mask")
+	errAmbiguousMask     = errors.New("ambiguous mask, use ParseBlockWithMask or ParseBlockWithWildcard")
+)
+
+// ParseBlockWithMask returns the CIDR block for addr and the netmask in dotted
+// notation, e.g. ("10.0.0.0", "255.0.0.0") returns 10.0.0.0/8.
+// Host bits in addr are masked off, like in ParseBlock.
+//
+// Returns Block{} and error on invalid input or non-contiguous netmasks.
+func ParseBlockWithMask(addr, netmask string) (Block, error) {
+	return blockFromMask(addr, netmask, false)
+}
+
+// ParseBlockWithWildcard returns the CIDR block for addr and the wildcard mask (inverse netmask)
+// as used in ACLs, e.g. ("10.0.0.0", "0.0.0.255") returns 10.0.0.0/24.
+// Host bits in addr are masked off, like in ParseBlock.
+//
+// Returns Block{} and error on invalid input or non-contiguous wildcard masks.
+func ParseBlockWithWildcard(addr, wildcard string) (Block, error) {
+	return blockFromMask(addr, wildcard, true)
+}
+
+// blockFromMaskString parses s in "addr mask" notation, separated by white space.
+// The mask may be a netmask or a wildcard mask:
+//
+//  10.0.0.0 255.0.0.0      // netmask
+//  10.0.0.0 0.255.255.255  // wildcard mask
+//
+// A mask with the most significant bit set is a netmask, else a wildcard mask.
+// The all-zeros mask is the host wildcard of ACLs, e.g. "10.0.0.1 0.0.0.0" is 10.0.0.1/32,
+// never the netmask of the default route.
+// The all-ones mask is ambiguous, "0.0.0.0 255.255.255.255" is "any" in ACLs or the host
+// 0.0.0.0 with a netmask, it returns an error.
+// Use ParseBlockWithMask or ParseBlockWithWildcard for these masks.
+func blockFromMaskString(s string) (Block, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return blockZero, errInvalidBlock
+	}
+	addr, mask := fields[0], fields[1]
+
+	m, err := ipFromString(mask)
+	if err != nil {
+		return blockZero, errInvalidMask
+	}
+
+	// all ones, netmask /32 or wildcard /0
+	if m == m.hostMask() {
+		return blockZero, errAmbiguousMask
+	}
+
+	// netmask if most significant bit is set
+	wildcard := m.Bytes()[0]&0x80 == 0
+
+	return blockFromMask(addr, mask, wildcard)
+}
+
+// blockFromMask is the common helper for netmask and wildcard mask parsing.
+func blockFromMask(addr, mask string, wildcard bool) (Block, error) {
+	ip, err := ipFromString(addr)
+	if err != nil {
+		return blockZero, errInvalidBlock
+	}
+
+	m, err := ipFromString(mask)
+	if err != nil {
+		return blockZero, errInvalidMask
+	}
+
+	if ip.Version() != m.Version() {
+		return blockZero, errInvalidMask
+	}
+
+	if wildcard {
+		m = invertMask(m)
+	}
+
+	ones, err := prefixLenFromMask(m)
+	if err != nil {
+		return blockZero, err
+	}
+
+	return NewCIDR(ip, ones)
+}
+
+// prefixLenFromMask counts the leading ones of the netmask.
+// Returns error if the mask isn't contiguous, e.g. 255.0.255.0
+func prefixLenFromMask(mask IP) (int, error) {
+	ones := 0
+	seenZero := false
+
+	for _, b := range mask.Bytes() {
+		if seenZero {
+			if b != 0 {
+				return 0, errNonContiguousMask
+			}
+			continue
+		}
+
+		n := bits.LeadingZeros8(^b)
+		// rest of byte must be zero
+		if b<<uint(n) != 0 {
+			return 0, errNonContiguousMask
+		}
+
+		ones += n
+		if n < 8 {
+			seenZero = true
+		}
+	}
+	return ones, nil
+}
+
+// invertMask returns the bitwise complement, netmask <-> wildcard mask.
+func invertMask(mask IP) IP {
+	m := mask.Bytes()
+	inv := make([]byte, len(m))
+	for i := range m {
+		inv[i] = ^m[i]
+	}
+	return setBytes(inv)
+}
+
+// Wildcard returns the wildcard mask (inverse netmask, hostmask) of the CIDR,
+// e.g. 0.0.0.255 for 10.0.0.0/24.
+// Returns IP{} for ranges without CIDR mask.
+func (a Block) Wildcard() IP {
+	if !a.IsCIDR() {
+		return ipZero
+	}
+	return invertMask(a.Mask)
+}
+
+// NetmaskString returns the CIDR in "addr netmask" notation, e.g. "10.0.0.0 255.0.0.0".
+// Returns "" for ranges without CIDR mask.
+func (a Block) NetmaskString() string {
+	if !a.IsCIDR() {
+		return ""
+	}
+	return a.Base.String() + " " + a.Mask.String()
+}
+
+// WildcardString returns the CIDR in "addr wildcard" notation as used in ACLs,
+// e.g. "10.0.0.0 0.255.255.255".
+// Returns "" for ranges without CIDR mask.
+func (a Block) WildcardString() string {
+	if !a.IsCIDR() {
+		return ""
+	}
+	return a.Base.String() + " " + a.Wildcard().String()
+}
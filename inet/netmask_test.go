@@ -0,0 +1,114 @@
+package inet
+
+import "testing"
+
+func TestParseBlockMaskNotation(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"10.0.0.0/255.0.0.0", "10.0.0.0/8"},
+		{"10.1.2.3/255.255.0.0", "10.1.0.0/16"},
+		{"10.0.0.0 255.0.0.0", "10.0.0.0/8"},
+		{"10.0.0.0   255.255.255.0", "10.0.0.0/24"},
+		{"10.0.0.0\t255.255.255.128", "10.0.0.0/25"},
+		{"10.0.0.0 0.0.0.255", "10.0.0.0/24"},
+		{"10.0.0.0 0.255.255.255", "10.0.0.0/8"},
+		{"0.0.0.0 128.0.0.0", "0.0.0.0/1"},
+		{"10.0.0.1 0.0.0.0", "10.0.0.1/32"},
+		{"0.0.0.0 0.0.0.0", "0.0.0.0/32"},
+		{"2001:db8::1 ::", "2001:db8::1/128"},
+		{"2001:db8::/ffff:ffff::", "2001:db8::/32"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBlock(tt.in)
+		if err != nil {
+			t.Errorf("ParseBlock(%q), got error %s", tt.in, err)
+			continue
+		}
+		if got != MustBlock(tt.want) {
+			t.Errorf("ParseBlock(%q) = %v, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseBlockMaskNotationFail(t *testing.T) {
+	tests := []string{
+		"10.0.0.0/255.0.255.0",
+		"10.0.0.0/0.0.0.255",
+		"10.0.0.0 255.0.255.0",
+		"10.0.0.0 0.255.0.255",
+		"10.0.0.0 0.0.0.256",
+		"10.0.0.0 255.0.0.0 0.0.0.0",
+		"10.0.0.0 ffff::",
+		"10.0.0.0/ffff::",
+		"2001:db8:: 255.255.0.0",
+	}
+
+	for _, in := range tests {
+		if _, err := ParseBlock(in); err == nil {
+			t.Errorf("success for ParseBlock(%q) is not expected!", in)
+		}
+	}
+}
+
+func TestParseBlockMaskNotationAmbiguous(t *testing.T) {
+	tests := []string{
+		"0.0.0.0 255.255.255.255", // ACL any or host 0.0.0.0
+		"10.0.0.1 255.255.255.255",
+		"2001:db8::1 ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+	}
+
+	for _, in := range tests {
+		if got, err := ParseBlock(in); err != errAmbiguousMask {
+			t.Errorf("ParseBlock(%q) = %v, %v, want error %v", in, got, err, errAmbiguousMask)
+		}
+	}
+
+	// explicit mask types are not ambiguous
+	if got, err := ParseBlockWithWildcard("0.0.0.0", "255.255.255.255"); err != nil || got != MustBlock("0.0.0.0/0") {
+		t.Errorf("ParseBlockWithWildcard(any), got %v, %v", got, err)
+	}
+	if got, err := ParseBlockWithMask("0.0.0.0", "0.0.0.0"); err != nil || got != MustBlock("0.0.0.0/0") {
+		t.Errorf("ParseBlockWithMask(default), got %v, %v", got, err)
+	}
+}
+
+func TestParseBlockWithMask(t *testing.T) {
+	if got, err := ParseBlockWithMask("192.168.1.0", "255.255.255.0"); err != nil || got != MustBlock("192.168.1.0/24") {
+		t.Errorf("ParseBlockWithMask, got %v, %v", got, err)
+	}
+	if got, err := ParseBlockWithMask("192.168.1.0", "0.0.0.0"); err != nil || got != MustBlock("0.0.0.0/0") {
+		t.Errorf("ParseBlockWithMask, got %v, %v", got, err)
+	}
+	if got, err := ParseBlockWithWildcard("192.168.1.0", "0.0.0.0"); err != nil || got != MustBlock("192.168.1.0/32") {
+		t.Errorf("ParseBlockWithWildcard, got %v, %v", got, err)
+	}
+	if _, err := ParseBlockWithWildcard("192.168.1.0", "0.0.255.0"); err != errNonContiguousMask {
+		t.Errorf("ParseBlockWithWildcard, got %v, want %v", err, errNonContiguousMask)
+	}
+}
+
+func TestBlockMaskString(t *testing.T) {
+	tests := []struct {
+		in       string
+		netmask  string
+		wildcard string
+	}{
+		{"10.0.0.0/8", "10.0.0.0 255.0.0.0", "10.0.0.0 0.255.255.255"},
+		{"192.168.1.0/26", "192.168.1.0 255.255.255.192", "192.168.1.0 0.0.0.63"},
+		{"10.0.0.1", "10.0.0.1 255.255.255.255", "10.0.0.1 0.0.0.0"},
+		{"10.0.0.1-10.0.0.2", "", ""},
+	}
+
+	for _, tt := range tests {
+		a := MustBlock(tt.in)
+		if got := a.NetmaskString(); got != tt.netmask {
+			t.Errorf("(%s).NetmaskString() = %q, want %q", tt.in, got, tt.netmask)
+		}
+		if got := a.WildcardString(); got != tt.wildcard {
+			t.Errorf("(%s).WildcardString() = %q, want %q", tt.in, got, tt.wildcard)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package inet
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var errNoClassless = errors.New("no RFC 2317 classless delegation")
+
+const (
+	reverseZoneV4 = "in-addr.arpa"
+	reverseZoneV6 = "ip6.arpa"
+)
+
+// CNAME is a DNS CNAME record, Name is an alias for Target.
+type CNAME struct {
+	Name   string
+	Target string
+}
+
+// ReverseName returns the full reverse DNS name of ip, e.g. 1.2.0.192.in-addr.arpa for 192.0.2.1.
+func (ip IP) ReverseName() string {
+	return ip.Reverse() + "." + reverseName(ip, 0)
+}
+
+// ReverseZones returns the names of the reverse DNS zones exactly covering the block,
+// without trailing dot, sorted.
+//
+// CIDRs on octet (IPv4) or nibble (IPv6) boundaries have a single zone, e.g.
+//
+//  192.0.2.0/24   ->  2.0.192.in-addr.arpa
+//  2001:db8::/32  ->  8.b.d.0.1.0.0.2.ip6.arpa
+//
+// other CIDRs are expanded to the zones of the next boundary, e.g.
+//
+//  10.0.0.0/15    ->  0.10.in-addr.arpa, 1.10.in-addr.arpa
+//  2001:db8::/31  ->  8.b.d.0.1.0.0.2.ip6.arpa, 9.b.d.0.1.0.0.2.ip6.arpa
+//
+// IPv4 CIDRs longer than /24 get the RFC 2317 classless zone name, e.g.
+//
+//  192.0.2.0/26   ->  0/26.2.0.192.in-addr.arpa
+//
+// the CNAMEs needed in the parent zone are returned by ClasslessCNAMEs.
+// Ranges are split into CIDRs, see BlockToCIDRList.
+func (a Block) ReverseZones() []string {
+	var out []string
+	for _, cidr := range a.BlockToCIDRList() {
+		out = append(out, cidr.reverseZones()...)
+	}
+	return out
+}
+
+// reverseZones for CIDRs
+func (a Block) reverseZones() []string {
+	bits, _ := a.PrefixLen()
+
+	// bits per label, octets or nibbles
+	step := 4
+	if a.Version() == 4 {
+		step = 8
+		if bits > 24 {
+			return []string{a.classlessZone(bits)}
+		}
+	}
+
+	// round up to label boundary
+	labels := (bits + step - 1) / step
+	zoneBits := labels * step
+
+	var out []string
+	ip := a.Base
+	for i := 0; i < 1<<uint(zoneBits-bits); i++ {
+		out = append(out, reverseName(ip, labels))
+		ip, _ = ip.addPow2(ip.bitLen() - zoneBits)
+	}
+	return out
+}
+
+// ClasslessCNAMEs returns the CNAME records for the parent /24 zone to delegate an IPv4 CIDR
+// longer than /24, RFC 2317. Every address in the parent zone is an alias for the name
+// in the classless zone, e.g. for 192.0.2.0/26
+//
+//  0.2.0.192.in-addr.arpa   -> 0.0/26.2.0.192.in-addr.arpa
+//  1.2.0.192.in-addr.arpa   -> 1.0/26.2.0.192.in-addr.arpa
+//  ...
+//  63.2.0.192.in-addr.arpa  -> 63.0/26.2.0.192.in-addr.arpa
+//
+// Returns error for IPv6, CIDRs up to /24 or if the block is no CIDR.
+func (a Block) ClasslessCNAMEs() ([]CNAME, error) {
+	bits, ok := a.PrefixLen()
+	if !ok || a.Version() != 4 || bits <= 24 {
+		return nil, errNoClassless
+	}
+
+	zone := a.classlessZone(bits)
+
+	out := make([]CNAME, 0, 1<<uint(32-bits))
+	for ip, ok := a.Base, true; ok && ip.Compare(a.Last) <= 0; ip, ok = ip.next() {
+		host := strconv.Itoa(int(ip[4]))
+		out = append(out, CNAME{Name: ip.ReverseName(), Target: host + "." + zone})
+	}
+	return out, nil
+}
+
+// classlessZone returns the RFC 2317 zone name, e.g. 0/26.2.0.192.in-addr.arpa
+func (a Block) classlessZone(bits int) string {
+	return strconv.Itoa(int(a.Base[4])) + "/" + strconv.Itoa(bits) + "." + reverseName(a.Base, 3)
+}
+
+// reverseName returns the reverse DNS name of the first labels (octets or nibbles) of ip.
+func reverseName(ip IP, labels int) string {
+	suffix := reverseZoneV6
+	if ip.Version() == 4 {
+		suffix = reverseZoneV4
+	}
+
+	if labels == 0 {
+		return suffix
+	}
+
+	rev := strings.Split(ip.Reverse(), ".")
+	return strings.Join(rev[len(rev)-labels:], ".") + "." + suffix
+}
@@ -0,0 +1,90 @@
+package inet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIPReverseName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"192.0.2.1", "1.2.0.192.in-addr.arpa"},
+		{"2001:db8::1", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+	}
+
+	for _, tt := range tests {
+		if got := MustIP(tt.in).ReverseName(); got != tt.want {
+			t.Errorf("%v.ReverseName() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBlockReverseZones(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"0.0.0.0/0", []string{"in-addr.arpa"}},
+		{"10.0.0.0/8", []string{"10.in-addr.arpa"}},
+		{"192.0.2.0/24", []string{"2.0.192.in-addr.arpa"}},
+		{"10.0.0.0/7", []string{"10.in-addr.arpa", "11.in-addr.arpa"}},
+		{"192.0.2.0/22", []string{"0.0.192.in-addr.arpa", "1.0.192.in-addr.arpa", "2.0.192.in-addr.arpa", "3.0.192.in-addr.arpa"}},
+		{"192.0.2.0/26", []string{"0/26.2.0.192.in-addr.arpa"}},
+		{"192.0.2.64/27", []string{"64/27.2.0.192.in-addr.arpa"}},
+		{"192.0.2.5/32", []string{"5/32.2.0.192.in-addr.arpa"}},
+		{"192.0.2.0-192.0.3.127", []string{"2.0.192.in-addr.arpa", "0/25.3.0.192.in-addr.arpa"}},
+		{"::/0", []string{"ip6.arpa"}},
+		{"2001:db8::/32", []string{"8.b.d.0.1.0.0.2.ip6.arpa"}},
+		{"2001:db8::/31", []string{"8.b.d.0.1.0.0.2.ip6.arpa", "9.b.d.0.1.0.0.2.ip6.arpa"}},
+		{"2001:db8::/34", []string{
+			"0.8.b.d.0.1.0.0.2.ip6.arpa",
+			"1.8.b.d.0.1.0.0.2.ip6.arpa",
+			"2.8.b.d.0.1.0.0.2.ip6.arpa",
+			"3.8.b.d.0.1.0.0.2.ip6.arpa",
+		}},
+		{"2001:db8::/127", []string{
+			"0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+			"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		}},
+	}
+
+	for _, tt := range tests {
+		if got := MustBlock(tt.in).ReverseZones(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v.ReverseZones() = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	if got := MustBlock("0.0.0.0/1").ReverseZones(); len(got) != 128 || got[127] != "127.in-addr.arpa" {
+		t.Errorf("0.0.0.0/1.ReverseZones(), got %d zones", len(got))
+	}
+}
+
+func TestBlockClasslessCNAMEs(t *testing.T) {
+	got, err := MustBlock("192.0.2.64/30").ClasslessCNAMEs()
+	if err != nil {
+		t.Fatalf("ClasslessCNAMEs(), unexpected error: %v", err)
+	}
+
+	want := []CNAME{
+		{"64.2.0.192.in-addr.arpa", "64.64/30.2.0.192.in-addr.arpa"},
+		{"65.2.0.192.in-addr.arpa", "65.64/30.2.0.192.in-addr.arpa"},
+		{"66.2.0.192.in-addr.arpa", "66.64/30.2.0.192.in-addr.arpa"},
+		{"67.2.0.192.in-addr.arpa", "67.64/30.2.0.192.in-addr.arpa"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ClasslessCNAMEs() = %v, want %v", got, want)
+	}
+
+	got, _ = MustBlock("255.255.255.128/25").ClasslessCNAMEs()
+	if len(got) != 128 || got[127].Target != "255.128/25.255.255.255.in-addr.arpa" {
+		t.Errorf("255.255.255.128/25.ClasslessCNAMEs(), got %d CNAMEs, last %v", len(got), got[len(got)-1])
+	}
+
+	for _, s := range []string{"192.0.2.0/24", "2001:db8::/120", "192.0.2.1-192.0.2.5"} {
+		if _, err := MustBlock(s).ClasslessCNAMEs(); err == nil {
+			t.Errorf("%v.ClasslessCNAMEs(), expected error", s)
+		}
+	}
+}
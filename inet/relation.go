@@ -0,0 +1,107 @@
+package inet
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// Rel is the relation between two blocks, see Relation.
+type Rel int
+
+// The relations of block a to block b, returned by Relation(a, b).
+//
+//  RelEqual          a |-----|        RelContains       a |---------|
+//                    b |-----|                          b    |---|
+//
+//  RelContainedBy    a    |---|       RelOverlapsLeft   a |-----|
+//                    b |---------|                      b    |-----|
+//
+//  RelOverlapsRight  a    |-----|     RelAdjacentBefore a |---|
+//                    b |-----|                          b     |---|
+//
+//  RelAdjacentAfter  a     |---|      RelDisjointBefore a |---|
+//                    b |---|                            b       |---|
+//
+//  RelDisjointAfter  a       |---|
+//                    b |---|
+//
+// The zero value RelInvalid is never returned by Relation.
+const (
+	RelInvalid         Rel = iota // zero value, no relation
+	RelVersionMismatch            // a and b have different IP versions
+	RelEqual
+	RelContains
+	RelContainedBy
+	RelOverlapsLeft
+	RelOverlapsRight
+	RelAdjacentBefore
+	RelAdjacentAfter
+	RelDisjointBefore
+	RelDisjointAfter
+)
+
+var relNames = [...]string{
+	RelInvalid:         "Invalid",
+	RelVersionMismatch: "VersionMismatch",
+	RelEqual:           "Equal",
+	RelContains:        "Contains",
+	RelContainedBy:     "ContainedBy",
+	RelOverlapsLeft:    "OverlapsLeft",
+	RelOverlapsRight:   "OverlapsRight",
+	RelAdjacentBefore:  "AdjacentBefore",
+	RelAdjacentAfter:   "AdjacentAfter",
+	RelDisjointBefore:  "DisjointBefore",
+	RelDisjointAfter:   "DisjointAfter",
+}
+
+// String implements the fmt.Stringer interface.
+func (r Rel) String() string {
+	if r < 0 || int(r) >= len(relNames) {
+		return "Rel(" + strconv.Itoa(int(r)) + ")"
+	}
+	return relNames[r]
+}
+
+// Relation returns the relation of block a to block b, exactly one of the Rel constants.
+// Only the base and last addresses are compared, a CIDR is equal to the same range.
+// Panics on invalid blocks.
+func Relation(a, b Block) Rel {
+	if !a.IsValid() || !b.IsValid() {
+		panic(errInvalidBlock)
+	}
+
+	if a.Base[0] != b.Base[0] {
+		return RelVersionMismatch
+	}
+
+	switch {
+	case bytes.Compare(a.Last[:], b.Base[:]) < 0:
+		if isAdjacent(a.Last, b.Base) {
+			return RelAdjacentBefore
+		}
+		return RelDisjointBefore
+
+	case bytes.Compare(b.Last[:], a.Base[:]) < 0:
+		if isAdjacent(b.Last, a.Base) {
+			return RelAdjacentAfter
+		}
+		return RelDisjointAfter
+	}
+
+	// overlapping
+	cmpBase := bytes.Compare(a.Base[:], b.Base[:])
+	cmpLast := bytes.Compare(a.Last[:], b.Last[:])
+
+	switch {
+	case cmpBase == 0 && cmpLast == 0:
+		return RelEqual
+	case cmpBase <= 0 && cmpLast >= 0:
+		return RelContains
+	case cmpBase >= 0 && cmpLast <= 0:
+		return RelContainedBy
+	case cmpBase < 0:
+		return RelOverlapsLeft
+	default:
+		return RelOverlapsRight
+	}
+}
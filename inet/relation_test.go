@@ -0,0 +1,141 @@
+package inet
+
+import (
+	"testing"
+)
+
+func TestRelation(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want Rel
+	}{
+		{
+			a:    "10.0.0.0/8",
+			b:    "10.0.0.0/8",
+			want: RelEqual,
+		},
+		{
+			a:    "10.0.0.0/24",
+			b:    "10.0.0.0-10.0.0.255",
+			want: RelEqual,
+		},
+		{
+			a:    "10.0.0.0/8",
+			b:    "10.1.0.0/16",
+			want: RelContains,
+		},
+		{
+			a:    "10.0.0.0/8",
+			b:    "10.0.0.0/16",
+			want: RelContains,
+		},
+		{
+			a:    "10.0.0.0/8",
+			b:    "10.255.0.0/16",
+			want: RelContains,
+		},
+		{
+			a:    "10.1.0.0/16",
+			b:    "10.0.0.0/8",
+			want: RelContainedBy,
+		},
+		{
+			a:    "10.0.0.0/16",
+			b:    "10.0.0.0/8",
+			want: RelContainedBy,
+		},
+		{
+			a:    "10.0.0.5-10.0.0.15",
+			b:    "10.0.0.10-10.0.0.20",
+			want: RelOverlapsLeft,
+		},
+		{
+			a:    "10.0.0.5-10.0.0.10",
+			b:    "10.0.0.10-10.0.0.20",
+			want: RelOverlapsLeft,
+		},
+		{
+			a:    "10.0.0.10-10.0.0.20",
+			b:    "10.0.0.5-10.0.0.15",
+			want: RelOverlapsRight,
+		},
+		{
+			a:    "10.0.0.0/25",
+			b:    "10.0.0.128/25",
+			want: RelAdjacentBefore,
+		},
+		{
+			a:    "10.0.0.128/25",
+			b:    "10.0.0.0/25",
+			want: RelAdjacentAfter,
+		},
+		{
+			a:    "10.0.0.0/25",
+			b:    "10.0.1.0/25",
+			want: RelDisjointBefore,
+		},
+		{
+			a:    "10.0.1.0/25",
+			b:    "10.0.0.0/25",
+			want: RelDisjointAfter,
+		},
+		{
+			a:    "255.255.255.255/32",
+			b:    "::/128",
+			want: RelVersionMismatch,
+		},
+		{
+			a:    "::/0",
+			b:    "0.0.0.0/0",
+			want: RelVersionMismatch,
+		},
+		{
+			a:    "2001:db8::/32",
+			b:    "2001:db8::/48",
+			want: RelContains,
+		},
+		{
+			a:    "2001:db8::/33",
+			b:    "2001:db8:8000::/33",
+			want: RelAdjacentBefore,
+		},
+		{
+			a:    "2001:db8::1-2001:db8::ff",
+			b:    "2001:db8::/120",
+			want: RelContainedBy,
+		},
+		{
+			a:    "2001:db8::ff-2001:db8::1:0",
+			b:    "2001:db8::/112",
+			want: RelOverlapsRight,
+		},
+	}
+
+	for _, tt := range tests {
+		got := Relation(MustBlock(tt.a), MustBlock(tt.b))
+		if got != tt.want {
+			t.Errorf("Relation(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRelationString(t *testing.T) {
+	if got := RelOverlapsLeft.String(); got != "OverlapsLeft" {
+		t.Errorf("RelOverlapsLeft.String() = %q, want %q", got, "OverlapsLeft")
+	}
+	if got := Rel(0).String(); got != "Invalid" {
+		t.Errorf("Rel(0).String() = %q, want %q", got, "Invalid")
+	}
+	if got := Rel(42).String(); got != "Rel(42)" {
+		t.Errorf("Rel(42).String() = %q, want %q", got, "Rel(42)")
+	}
+}
+
+func TestRelationPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Relation(Block{}, ...), expected panic")
+		}
+	}()
+	Relation(Block{}, MustBlock("10.0.0.0/8"))
+}
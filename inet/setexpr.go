@@ -0,0 +1,218 @@
+package inet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetExprError describes a syntax or evaluation error in a set expression,
+// Pos is the byte offset in the expression.
+type SetExprError struct {
+	Expr string
+	Pos  int
+	Msg  string
+}
+
+// Error implements the error interface.
+func (e *SetExprError) Error() string {
+	return fmt.Sprintf("set expression %q: pos %d: %s", e.Expr, e.Pos, e.Msg)
+}
+
+// universes for the complement operator, IPv4 and IPv6 address space
+var setExprUniverse = []Block{
+	{Base: IP{4}, Last: ipMaxV4, Mask: IP{4}},
+	{Base: IP{6}, Last: ipMaxV6, Mask: IP{6}},
+}
+
+// EvalSetExpr evaluates an address set expression and returns the normalized result,
+// see Union. Use Aggregate to get the result as CIDRs.
+//
+// Operators:
+//
+//  a + b    union
+//  a - b    difference
+//  a & b    intersection
+//  !a       complement, within 0.0.0.0/0 and ::/0
+//  (a)      grouping
+//
+// The binary operators '+', '-' and '&' have the same precedence and are left associative,
+// the expression is evaluated from left to right. '!' binds tighter. The operands are blocks
+// in any string notation accepted by ParseBlock, without white space, or names of sets
+// supplied by the caller, e.g.
+//
+//  10.0.0.0/8 - 10.1.0.0/16 + 192.168.0.0/16 & !bogons
+//
+// is evaluated as ((10.0.0.0/8 - 10.1.0.0/16) + 192.168.0.0/16) & !bogons,
+// the bogons are filtered from the whole expression. Note, this differs from languages where
+// '&' binds tighter than '+' and '-': with the usual bogon list, containing the RFC 1918
+// networks, the result of this example is empty. Use parentheses to filter just an operand:
+//
+//  10.0.0.0/8 - 10.1.0.0/16 + (192.168.0.0/16 & !bogons)
+//
+// Since '-' is also the separator in begin-end ranges, the difference operator
+// must not be attached to the end of an operand, "10.0.0.0/8 - 10.1.0.0/16" is a difference
+// and "10.0.0.1-10.0.0.5" a range.
+//
+// Returns a *SetExprError with the position on syntax errors, invalid blocks and unknown names.
+func EvalSetExpr(expr string, sets map[string][]Block) ([]Block, error) {
+	p := &setExprParser{expr: expr, sets: sets}
+	p.advance()
+
+	if p.tok == "" {
+		return nil, p.errorf("empty expression")
+	}
+
+	result, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok != "" {
+		return nil, p.errorf("unexpected %q", p.tok)
+	}
+
+	return normalize(result), nil
+}
+
+// setExprParser is a recursive descent parser, evaluating while parsing.
+type setExprParser struct {
+	expr string
+	sets map[string][]Block
+
+	pos    int    // scan position in expr
+	tok    string // current token, "" at end of input
+	tokPos int    // position of current token
+}
+
+// advance scans the next token.
+func (p *setExprParser) advance() {
+	// skip white space
+	for p.pos < len(p.expr) && isSetExprSpace(p.expr[p.pos]) {
+		p.pos++
+	}
+
+	p.tokPos = p.pos
+	if p.pos == len(p.expr) {
+		p.tok = ""
+		return
+	}
+
+	// single char operators, operands never start with '-'
+	if strings.IndexByte("()+-&!", p.expr[p.pos]) >= 0 {
+		p.tok = p.expr[p.pos : p.pos+1]
+		p.pos++
+		return
+	}
+
+	// operand, up to white space or operator, '-' is part of ranges
+	for p.pos < len(p.expr) && !isSetExprSpace(p.expr[p.pos]) && strings.IndexByte("()+&!", p.expr[p.pos]) < 0 {
+		p.pos++
+	}
+	p.tok = p.expr[p.tokPos:p.pos]
+}
+
+func isSetExprSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func (p *setExprParser) errorf(format string, args ...interface{}) error {
+	return &SetExprError{Expr: p.expr, Pos: p.tokPos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// expr = unary { ('+' | '-' | '&') unary }
+func (p *setExprParser) parseExpr() ([]Block, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok == "+" || p.tok == "-" || p.tok == "&" {
+		op := p.tok
+		p.advance()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case "+":
+			left = Union(left, right)
+		case "-":
+			left = Difference(left, right)
+		default:
+			left = Intersect(left, right)
+		}
+	}
+
+	return left, nil
+}
+
+// unary = '!' unary | primary
+func (p *setExprParser) parseUnary() ([]Block, error) {
+	if p.tok == "!" {
+		p.advance()
+
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Difference(setExprUniverse, x), nil
+	}
+
+	return p.parsePrimary()
+}
+
+// primary = '(' expr ')' | block | name
+func (p *setExprParser) parsePrimary() ([]Block, error) {
+	switch p.tok {
+	case "":
+		return nil, p.errorf("unexpected end of expression")
+	case "(":
+		p.advance()
+
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.tok != ")" {
+			return nil, p.errorf("missing ')'")
+		}
+		p.advance()
+		return x, nil
+	case ")", "+", "-", "&":
+		return nil, p.errorf("unexpected %q", p.tok)
+	}
+
+	// block or name
+	if b, err := ParseBlock(p.tok); err == nil {
+		p.advance()
+		return []Block{b}, nil
+	}
+
+	if isSetExprName(p.tok) {
+		bs, ok := p.sets[p.tok]
+		if !ok {
+			return nil, p.errorf("unknown set %q", p.tok)
+		}
+		p.advance()
+		return bs, nil
+	}
+
+	return nil, p.errorf("invalid block %q", p.tok)
+}
+
+// isSetExprName reports whether s is a valid set name: letter or '_', followed by letters, digits, '_' or '-'
+func isSetExprName(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		case i > 0 && (c >= '0' && c <= '9' || c == '-'):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,111 @@
+package inet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvalSetExpr(t *testing.T) {
+	sets := map[string][]Block{
+		"bogons":   blocks("10.0.0.0/8", "192.168.0.0/16", "fc00::/7"),
+		"rfc1918":  blocks("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"),
+		"office_1": blocks("192.168.1.0/24"),
+		"empty":    nil,
+	}
+
+	tests := []struct {
+		expr string
+		want []Block
+	}{
+		{"10.0.0.0/8", blocks("10.0.0.0/8")},
+		{"10.0.0.0/8 - 10.1.0.0/16", blocks("10.0.0.0/16", "10.2.0.0-10.255.255.255")},
+		{"10.0.0.0/8 -10.128.0.0/9", blocks("10.0.0.0/9")},
+		{"10.0.0.0/25 + 10.0.0.128/25", blocks("10.0.0.0/24")},
+		{"10.0.0.0/8 & 10.1.0.0/16", blocks("10.1.0.0/16")},
+		{"10.0.0.1-10.0.0.5", blocks("10.0.0.1-10.0.0.5")},
+		{"10.0.0.0/24 - 10.0.0.1-10.0.0.254", blocks("10.0.0.0/32", "10.0.0.255/32")},
+		{"office_1 + 2001:db8::/32", blocks("192.168.1.0/24", "2001:db8::/32")},
+		{"rfc1918 & !bogons", blocks("172.16.0.0/12")},
+		{"!!office_1", blocks("192.168.1.0/24")},
+		{"!(0.0.0.0/1 + ::/1)", blocks("128.0.0.0/1", "8000::/1")},
+		{"10.0.0.0/8 - 10.1.0.0/16 + 192.168.0.0/16 & !bogons", nil},
+		{"(10.0.0.0/8 - 10.1.0.0/16 + 192.168.0.0/16) & !bogons", nil},
+		{"10.0.0.0/8 - 10.1.0.0/16 + (192.168.0.0/16 & !bogons)", blocks("10.0.0.0/16", "10.2.0.0-10.255.255.255")},
+		{"rfc1918 & !bogons + 10.0.0.0/8", blocks("10.0.0.0/8", "172.16.0.0/12")},
+		{"10.0.0.0/8 + 172.16.0.0/12 & 172.0.0.0/8 - 172.31.0.0/16", blocks("172.16.0.0-172.30.255.255")},
+		{"10.0.0.0/8 - (10.1.0.0/16 + 10.2.0.0/16)", blocks("10.0.0.0/16", "10.3.0.0-10.255.255.255")},
+		{"empty + 10.0.0.0/8", blocks("10.0.0.0/8")},
+		{" ( 172.16/12 ) ", blocks("172.16.0.0/12")},
+	}
+
+	for _, tt := range tests {
+		got, err := EvalSetExpr(tt.expr, sets)
+		if err != nil {
+			t.Errorf("EvalSetExpr(%q), got error %s", tt.expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("EvalSetExpr(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+// the example from the EvalSetExpr doc with the usual IPv4 bogons,
+// left associative the bogons are filtered from the whole expression
+func TestEvalSetExprDocExample(t *testing.T) {
+	sets := map[string][]Block{
+		"bogons": blocks(
+			"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16", "172.16.0.0/12",
+			"192.0.0.0/24", "192.0.2.0/24", "192.168.0.0/16", "198.18.0.0/15", "198.51.100.0/24",
+			"203.0.113.0/24", "224.0.0.0/4", "240.0.0.0/4",
+		),
+	}
+
+	got, err := EvalSetExpr("10.0.0.0/8 - 10.1.0.0/16 + 192.168.0.0/16 & !bogons", sets)
+	if err != nil || got != nil {
+		t.Errorf("EvalSetExpr(doc example) = %v, %v, want empty set", got, err)
+	}
+
+	want := blocks("10.0.0.0/16", "10.2.0.0-10.255.255.255")
+	got, err = EvalSetExpr("10.0.0.0/8 - 10.1.0.0/16 + (192.168.0.0/16 & !bogons)", sets)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("EvalSetExpr(doc example with parens) = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestEvalSetExprError(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{"", 0},
+		{"   ", 3},
+		{"10.0.0.0/8 +", 12},
+		{"10.0.0.0/8 + + 10.0.0.0/8", 13},
+		{"(10.0.0.0/8", 11},
+		{"10.0.0.0/8)", 10},
+		{"10.0.0.0/8 10.0.0.0/8", 11},
+		{"10.0.0.0/8 & unknown", 13},
+		{"10.0.0.0/8- 10.1.0.0/16", 0},
+		{"10.0.0.0/33", 0},
+		{"10.0.0.0/8 + 1nvalid", 13},
+		{"!", 1},
+		{"()", 1},
+	}
+
+	for _, tt := range tests {
+		_, err := EvalSetExpr(tt.expr, nil)
+		if err == nil {
+			t.Errorf("EvalSetExpr(%q), expected error", tt.expr)
+			continue
+		}
+		e, ok := err.(*SetExprError)
+		if !ok {
+			t.Errorf("EvalSetExpr(%q), error is no *SetExprError: %T", tt.expr, err)
+			continue
+		}
+		if e.Pos != tt.pos {
+			t.Errorf("EvalSetExpr(%q), error %q at pos %d, want pos %d", tt.expr, e, e.Pos, tt.pos)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package inet
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Intersect returns the common part of the Blocks a and b and true,
+// or Block{} and false if a and b are disjunct.
+//
+//  a    |-------|
+//  b |------|
+//  =    |---|
+func (a Block) Intersect(b Block) (Block, bool) {
+	if a.IsDisjunctWith(b) {
+		return blockZero, false
+	}
+
+	base, last := a.Base, a.Last
+	if bytes.Compare(b.Base[:], base[:]) > 0 {
+		base = b.Base
+	}
+	if bytes.Compare(b.Last[:], last[:]) < 0 {
+		last = b.Last
+	}
+	return newRange(base, last), true
+}
+
+// Subtract returns the remaining parts of Block a after removing Block b, ordered by Compare.
+// Returns zero, one or two Blocks, the Blocks may be ranges and not CIDRs.
+//
+//  a |------------|
+//  b     |---|
+//  = |---|   |----|
+func (a Block) Subtract(b Block) []Block {
+	if a.IsDisjunctWith(b) {
+		return []Block{a}
+	}
+
+	out := make([]Block, 0, 2)
+
+	// left part
+	if bytes.Compare(a.Base[:], b.Base[:]) < 0 {
+		last, _ := b.Base.prev()
+		out = append(out, newRange(a.Base, last))
+	}
+
+	// right part
+	if bytes.Compare(a.Last[:], b.Last[:]) > 0 {
+		base, _ := b.Last.next()
+		out = append(out, newRange(base, a.Last))
+	}
+
+	return out
+}
+
+// Union returns the address space covered by a or b.
+//
+// The result is normalized: sorted, non-overlapping and adjacent blocks are merged to ranges.
+// IPv4 and IPv6 blocks may be mixed in the input. Use Aggregate to get the result as CIDRs.
+func Union(a, b []Block) []Block {
+	bs := make([]Block, 0, len(a)+len(b))
+	bs = append(bs, a...)
+	bs = append(bs, b...)
+	return normalize(bs)
+}
+
+// Intersect returns the address space covered by a and b.
+//
+// The result is normalized, see Union.
+func Intersect(a, b []Block) []Block {
+	a, b = normalize(a), normalize(b)
+
+	var out []Block
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if x, ok := a[i].Intersect(b[j]); ok {
+			out = append(out, x)
+		}
+
+		// advance the block ending first
+		if bytes.Compare(a[i].Last[:], b[j].Last[:]) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return out
+}
+
+// Difference returns the address space covered by a but not by b.
+//
+// The result is normalized, see Union.
+func Difference(a, b []Block) []Block {
+	a, b = normalize(a), normalize(b)
+
+	var out []Block
+	j := 0
+	for _, x := range a {
+		// skip blocks in b left of x
+		for j < len(b) && bytes.Compare(b[j].Last[:], x.Base[:]) < 0 {
+			j++
+		}
+
+		// cut off all blocks in b overlapping with x, from left to right
+		consumed := false
+		for k := j; k < len(b) && bytes.Compare(b[k].Base[:], x.Last[:]) <= 0; k++ {
+			// gap left of b[k]
+			if bytes.Compare(b[k].Base[:], x.Base[:]) > 0 {
+				last, _ := b[k].Base.prev()
+				out = append(out, newRange(x.Base, last))
+			}
+
+			// b[k] covers the rest of x
+			if bytes.Compare(b[k].Last[:], x.Last[:]) >= 0 {
+				consumed = true
+				break
+			}
+
+			x.Base, _ = b[k].Last.next()
+		}
+
+		if !consumed {
+			out = append(out, newRange(x.Base, x.Last))
+		}
+	}
+
+	return out
+}
+
+// Complement returns the address space of universe not covered by bs,
+// e.g. with universe 0.0.0.0/0 or ::/0.
+//
+// The result is normalized, see Union.
+func Complement(bs []Block, universe Block) []Block {
+	return Difference([]Block{universe}, bs)
+}
+
+// normalize returns the blocks sorted, with overlapping and adjacent blocks merged to ranges.
+// The input slice is not modified.
+func normalize(bs []Block) []Block {
+	if len(bs) == 0 {
+		return nil
+	}
+
+	sorted := make([]Block, len(bs))
+	copy(sorted, bs)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i].Base[:], sorted[j].Base[:]) < 0 })
+
+	out := make([]Block, 0, len(sorted))
+	cur := sorted[0]
+
+	for _, b := range sorted[1:] {
+		// overlapping or adjacent, no gap between cur and b
+		if bytes.Compare(b.Base[:], cur.Last[:]) <= 0 || isAdjacent(cur.Last, b.Base) {
+			if bytes.Compare(b.Last[:], cur.Last[:]) > 0 {
+				cur.Last = b.Last
+			}
+			continue
+		}
+		out = append(out, newRange(cur.Base, cur.Last))
+		cur = b
+	}
+	out = append(out, newRange(cur.Base, cur.Last))
+
+	return out
+}
+
+// isAdjacent reports whether next is the successor of last, within the same IP version.
+func isAdjacent(last, next IP) bool {
+	n, ok := last.next()
+	return ok && n == next
+}
+
+// newRange returns the Block from base to last, with CIDR mask if possible.
+func newRange(base, last IP) Block {
+	b := Block{Base: base, Last: last}
+	b.Mask = b.getMask()
+	return b
+}
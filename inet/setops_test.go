@@ -0,0 +1,186 @@
+package inet
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func blocks(ss ...string) []Block {
+	var out []Block
+	for _, s := range ss {
+		out = append(out, MustBlock(s))
+	}
+	return out
+}
+
+func TestBlockIntersect(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+		ok   bool
+	}{
+		{"10.0.0.0/8", "10.1.0.0/16", "10.1.0.0/16", true},
+		{"10.1.0.0/16", "10.0.0.0/8", "10.1.0.0/16", true},
+		{"10.0.0.0-10.0.0.10", "10.0.0.5-10.0.0.20", "10.0.0.5-10.0.0.10", true},
+		{"10.0.0.0/8", "10.0.0.0/8", "10.0.0.0/8", true},
+		{"10.0.0.0/31", "10.0.0.2/31", "", false},
+		{"0.0.0.0/0", "::/0", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := MustBlock(tt.a).Intersect(MustBlock(tt.b))
+		if ok != tt.ok || got.String() != tt.want {
+			t.Errorf("(%s).Intersect(%s) = (%v, %v), want (%s, %v)", tt.a, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBlockSubtract(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want []Block
+	}{
+		{"10.0.0.0/24", "10.0.0.0/25", blocks("10.0.0.128/25")},
+		{"10.0.0.0/24", "10.0.0.128/25", blocks("10.0.0.0/25")},
+		{"10.0.0.0/24", "10.0.0.10-10.0.0.20", blocks("10.0.0.0-10.0.0.9", "10.0.0.21-10.0.0.255")},
+		{"10.0.0.0/24", "10.0.0.0/8", []Block{}},
+		{"10.0.0.0/24", "10.0.0.0/24", []Block{}},
+		{"10.0.0.0/24", "10.0.1.0/24", blocks("10.0.0.0/24")},
+		{"0.0.0.0/0", "::/0", blocks("0.0.0.0/0")},
+		{"::/0", "::/1", blocks("8000::/1")},
+	}
+
+	for _, tt := range tests {
+		got := MustBlock(tt.a).Subtract(MustBlock(tt.b))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("(%s).Subtract(%s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	tests := []struct {
+		a, b []Block
+		want []Block
+	}{
+		{nil, nil, nil},
+		{blocks("10.0.0.0/25"), blocks("10.0.0.128/25"), blocks("10.0.0.0/24")},
+		{blocks("10.0.0.0/24", "10.0.0.10-10.0.1.5"), nil, blocks("10.0.0.0-10.0.1.5")},
+		{blocks("::/1", "0.0.0.0/1"), blocks("8000::/1", "128.0.0.0/1"), blocks("0.0.0.0/0", "::/0")},
+		{blocks("255.255.255.255"), blocks("::"), blocks("255.255.255.255/32", "::/128")},
+	}
+
+	for _, tt := range tests {
+		got := Union(tt.a, tt.b)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Union(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		a, b []Block
+		want []Block
+	}{
+		{nil, blocks("10.0.0.0/8"), nil},
+		{blocks("10.0.0.0/8", "::/0"), blocks("10.1.0.0/16", "2001:db8::/32", "11.0.0.0/8"), blocks("10.1.0.0/16", "2001:db8::/32")},
+		{blocks("10.0.0.0-10.0.0.10", "10.0.0.20-10.0.0.30"), blocks("10.0.0.5-10.0.0.25"), blocks("10.0.0.5-10.0.0.10", "10.0.0.20-10.0.0.25")},
+	}
+
+	for _, tt := range tests {
+		got := Intersect(tt.a, tt.b)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Intersect(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDifference(t *testing.T) {
+	tests := []struct {
+		a, b []Block
+		want []Block
+	}{
+		{nil, blocks("10.0.0.0/8"), nil},
+		{blocks("10.0.0.0/8"), nil, blocks("10.0.0.0/8")},
+		{blocks("10.0.0.0/8"), blocks("10.0.0.0/9"), blocks("10.128.0.0/9")},
+		{blocks("10.0.0.0/24"), blocks("10.0.0.10-10.0.0.19", "10.0.0.30-10.0.0.39"), blocks("10.0.0.0-10.0.0.9", "10.0.0.20-10.0.0.29", "10.0.0.40-10.0.0.255")},
+		{blocks("10.0.0.0/24", "10.0.2.0/24"), blocks("10.0.0.128-10.0.2.127"), blocks("10.0.0.0/25", "10.0.2.128/25")},
+		{blocks("0.0.0.0/0", "::/0"), blocks("::/0"), blocks("0.0.0.0/0")},
+	}
+
+	for _, tt := range tests {
+		got := Difference(tt.a, tt.b)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Difference(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestComplement(t *testing.T) {
+	got := Complement(blocks("0.0.0.0/1", "192.0.0.0/2"), MustBlock("0.0.0.0/0"))
+	want := blocks("128.0.0.0/2")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Complement, got %v, want %v", got, want)
+	}
+
+	got = Complement(nil, MustBlock("::/0"))
+	want = blocks("::/0")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Complement, got %v, want %v", got, want)
+	}
+
+	got = Complement(blocks("::/0"), MustBlock("::/0"))
+	if got != nil {
+		t.Errorf("Complement, got %v, want nil", got)
+	}
+}
+
+// compare the set operations with brute force membership tests in a small address space
+func TestSetOpsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	base := MustIP("10.0.0.0")
+
+	randBlocks := func() []Block {
+		var bs []Block
+		for n := r.Intn(5); n > 0; n-- {
+			lo, hi := uint64(r.Intn(256)), uint64(r.Intn(256))
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			bs = append(bs, newRange(base.AddUint64(lo), base.AddUint64(hi)))
+		}
+		return bs
+	}
+
+	containsAny := func(bs []Block, ip IP) bool {
+		for _, b := range bs {
+			if b.ContainsIP(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := randBlocks(), randBlocks()
+
+		union, isect, diff := Union(a, b), Intersect(a, b), Difference(a, b)
+
+		for k := uint64(0); k < 256; k++ {
+			ip := base.AddUint64(k)
+			inA, inB := containsAny(a, ip), containsAny(b, ip)
+
+			if containsAny(union, ip) != (inA || inB) {
+				t.Fatalf("Union(%v, %v) = %v, wrong for %v", a, b, union, ip)
+			}
+			if containsAny(isect, ip) != (inA && inB) {
+				t.Fatalf("Intersect(%v, %v) = %v, wrong for %v", a, b, isect, ip)
+			}
+			if containsAny(diff, ip) != (inA && !inB) {
+				t.Fatalf("Difference(%v, %v) = %v, wrong for %v", a, b, diff, ip)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package inet
+
+import (
+	"math/big"
+)
+
+// SplitEven divides the address space of the blocks into n contiguous chunks of near-equal
+// number of addresses, e.g. to parallelize scans. The chunk sizes differ at most by one address.
+// Every chunk is returned as sorted list of CIDRs, see BlockToCIDRList.
+//
+// Overlapping input blocks are counted only once. If there are less than n addresses,
+// only as many chunks as addresses are returned. Returns nil for n < 1 or empty input.
+func SplitEven(bs []Block, n int) [][]Block {
+	ranges := normalize(bs)
+	if n < 1 || len(ranges) == 0 {
+		return nil
+	}
+
+	// quota per chunk: the first rem chunks get one address more
+	quo, rem := new(big.Int).QuoRem(TotalSize(ranges), big.NewInt(int64(n)), new(big.Int))
+
+	i := int64(0)
+	return splitRanges(ranges, func() *big.Int {
+		q := new(big.Int).Set(quo)
+		if big.NewInt(i).Cmp(rem) < 0 {
+			q.Add(q, big.NewInt(1))
+		}
+		i++
+		return q
+	})
+}
+
+// SplitBySize divides the address space of the blocks into contiguous chunks of max addresses,
+// the last chunk may be smaller. Every chunk is returned as sorted list of CIDRs, see BlockToCIDRList.
+//
+// Overlapping input blocks are counted only once.
+// Returns nil for max == 0 or empty input. Beware, a huge IPv6 block results in a huge number of chunks.
+func SplitBySize(bs []Block, max uint64) [][]Block {
+	ranges := normalize(bs)
+	if max == 0 || len(ranges) == 0 {
+		return nil
+	}
+
+	return splitRanges(ranges, func() *big.Int { return new(big.Int).SetUint64(max) })
+}
+
+// splitRanges cuts the normalized ranges into chunks, the size of the next chunk is returned by quota.
+func splitRanges(ranges []Block, quota func() *big.Int) [][]Block {
+	var out [][]Block
+	var chunk []Block
+
+	need := quota()
+	for _, r := range ranges {
+		for {
+			// quota of 0 addresses, happens only with less addresses than chunks
+			if need.Sign() == 0 {
+				return out
+			}
+
+			size := r.SizeBig()
+
+			// r fits in current chunk
+			if size.Cmp(need) < 0 {
+				chunk = append(chunk, r)
+				need.Sub(need, size)
+				break
+			}
+
+			// cut r at quota, the chunk is full
+			last, _ := r.Nth(need.Sub(need, big.NewInt(1)))
+			chunk = append(chunk, newRange(r.Base, last))
+			out = append(out, cidrList(chunk))
+
+			chunk = nil
+			need = quota()
+
+			if last == r.Last {
+				break
+			}
+
+			base, _ := last.next()
+			r = newRange(base, r.Last)
+		}
+	}
+
+	if len(chunk) > 0 {
+		out = append(out, cidrList(chunk))
+	}
+
+	return out
+}
+
+// cidrList returns the ranges as list of CIDRs.
+func cidrList(ranges []Block) []Block {
+	out := make([]Block, 0, len(ranges))
+	for _, r := range ranges {
+		out = append(out, r.BlockToCIDRList()...)
+	}
+	return out
+}
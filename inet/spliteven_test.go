@@ -0,0 +1,90 @@
+package inet
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestSplitEven(t *testing.T) {
+	tests := []struct {
+		in   []Block
+		n    int
+		want [][]Block
+	}{
+		{nil, 2, nil},
+		{blocks("10.0.0.0/24"), 0, nil},
+		{blocks("10.0.0.0/24"), 1, [][]Block{blocks("10.0.0.0/24")}},
+		{blocks("10.0.0.0/24"), 4, [][]Block{blocks("10.0.0.0/26"), blocks("10.0.0.64/26"), blocks("10.0.0.128/26"), blocks("10.0.0.192/26")}},
+		{blocks("10.0.0.0/30", "10.0.0.128/30"), 2, [][]Block{blocks("10.0.0.0/30"), blocks("10.0.0.128/30")}},
+		{blocks("10.0.0.0/30", "10.0.0.128/31"), 2, [][]Block{blocks("10.0.0.0/31", "10.0.0.2/32"), blocks("10.0.0.3/32", "10.0.0.128/31")}},
+		{blocks("10.0.0.0-10.0.0.2"), 5, [][]Block{blocks("10.0.0.0/32"), blocks("10.0.0.1/32"), blocks("10.0.0.2/32")}},
+		{blocks("10.0.0.0/24", "10.0.0.0/25"), 2, [][]Block{blocks("10.0.0.0/25"), blocks("10.0.0.128/25")}},
+		{blocks("255.255.255.255", "::"), 2, [][]Block{blocks("255.255.255.255"), blocks("::")}},
+	}
+
+	for _, tt := range tests {
+		got := SplitEven(tt.in, tt.n)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitEven(%v, %d) = %v, want %v", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestSplitEvenSizes(t *testing.T) {
+	in := blocks("10.0.0.0/8", "192.168.0.0/16", "172.16.0.17-172.16.3.200", "2001:db8::/100")
+	total := TotalSize(in)
+
+	for _, n := range []int{1, 2, 3, 7, 100, 1000} {
+		chunks := SplitEven(in, n)
+		if len(chunks) != n {
+			t.Errorf("SplitEven(%d), got %d chunks", n, len(chunks))
+			continue
+		}
+
+		min, max := TotalSize(chunks[0]), TotalSize(chunks[0])
+		sum := new(big.Int)
+		for _, c := range chunks {
+			size := TotalSize(c)
+			sum.Add(sum, size)
+			if size.Cmp(min) < 0 {
+				min = size
+			}
+			if size.Cmp(max) > 0 {
+				max = size
+			}
+		}
+
+		if sum.Cmp(total) != 0 {
+			t.Errorf("SplitEven(%d), sum of chunks %v, want %v", n, sum, total)
+		}
+		if new(big.Int).Sub(max, min).Cmp(big.NewInt(1)) > 0 {
+			t.Errorf("SplitEven(%d), chunk sizes differ by more than 1: %v, %v", n, min, max)
+		}
+	}
+}
+
+func TestSplitBySize(t *testing.T) {
+	tests := []struct {
+		in   []Block
+		max  uint64
+		want [][]Block
+	}{
+		{blocks("10.0.0.0/24"), 0, nil},
+		{blocks("10.0.0.0/24"), 256, [][]Block{blocks("10.0.0.0/24")}},
+		{blocks("10.0.0.0/24"), 1000, [][]Block{blocks("10.0.0.0/24")}},
+		{blocks("10.0.0.0/24"), 100, [][]Block{
+			blocks("10.0.0.0/26", "10.0.0.64/27", "10.0.0.96/30"),
+			blocks("10.0.0.100/30", "10.0.0.104/29", "10.0.0.112/28", "10.0.0.128/26", "10.0.0.192/29"),
+			blocks("10.0.0.200/29", "10.0.0.208/28", "10.0.0.224/27"),
+		}},
+		{blocks("10.0.0.0/31", "10.0.0.4/31"), 3, [][]Block{blocks("10.0.0.0/31", "10.0.0.4/32"), blocks("10.0.0.5/32")}},
+	}
+
+	for _, tt := range tests {
+		got := SplitBySize(tt.in, tt.max)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitBySize(%v, %d) = %v, want %v", tt.in, tt.max, got, tt.want)
+		}
+	}
+}
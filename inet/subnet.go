@@ -0,0 +1,149 @@
+package inet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+)
+
+var (
+	errInvalidIndex = errors.New("subnet index out of range")
+	errNoSubnet     = errors.New("no subnet of Block")
+)
+
+// Subnet returns the subnet with prefix length newBits at position index in the CIDR a,
+// without materializing the other subnets, e.g. the /64 number 4711 in a /48:
+//
+//  a := inet.MustBlock("2001:db8:ab00::/48")
+//  s, _ := a.Subnet(64, big.NewInt(4711)) // 2001:db8:ab00:1267::/64
+//
+// Returns Block{} and error if a is no CIDR, newBits is shorter than the prefix length of a
+// or out of range for the IP version, or index is nil or out of range [0, 2^(newBits-bits)).
+func (a Block) Subnet(newBits int, index *big.Int) (Block, error) {
+	ones, ok := a.PrefixLen()
+	if !ok {
+		return blockZero, errNoCIDR
+	}
+
+	maxBits := a.Base.bitLen()
+	if newBits < ones || newBits > maxBits {
+		return blockZero, errInvalidPrefixLen
+	}
+
+	if index == nil || index.Sign() < 0 || index.BitLen() > newBits-ones {
+		return blockZero, errInvalidIndex
+	}
+
+	// base + index << hostbits
+	offset := new(big.Int).Lsh(index, uint(maxBits-newBits))
+	x := a.Base.toBig()
+
+	base, ok := a.Base.ipFromBig(x.Add(x, offset))
+	if !ok {
+		return blockZero, errInvalidIndex
+	}
+	return NewCIDR(base, newBits)
+}
+
+// SubnetUint64 is like Subnet, but with an uint64 index, sufficient for most use cases.
+func (a Block) SubnetUint64(newBits int, index uint64) (Block, error) {
+	return a.Subnet(newBits, new(big.Int).SetUint64(index))
+}
+
+// SubnetIndex returns the position of the CIDR child in the CIDR a, the inverse of Subnet.
+//
+// Returns nil and error if a or child is no CIDR, or child is no subnet of (or equal to) a.
+func (a Block) SubnetIndex(child Block) (*big.Int, error) {
+	ones, ok := a.PrefixLen()
+	if !ok {
+		return nil, errNoCIDR
+	}
+
+	childBits, ok := child.PrefixLen()
+	if !ok {
+		return nil, errNoCIDR
+	}
+
+	if childBits < ones || !(child == a || a.Contains(child)) {
+		return nil, errNoSubnet
+	}
+
+	// (child.base - base) >> hostbits
+	x := child.Base.toBig()
+	x.Sub(x, a.Base.toBig())
+	return x.Rsh(x, uint(a.Base.bitLen()-childBits)), nil
+}
+
+// SubnetIter iterates lazily over the subnets of a CIDR, see Block.Subnets.
+type SubnetIter struct {
+	ctx      context.Context
+	block    Block
+	next     IP
+	newBits  int
+	hostBits int
+	done     bool
+	err      error
+}
+
+// Subnets returns an iterator over all subnets with prefix length newBits in the CIDR a, in ascending order.
+// In contrast to SplitCIDR the subnets are generated on demand, there is no limit by MaxCIDRSplit,
+// even the /64 subnets of a /16 can be iterated.
+//
+// The iteration stops when ctx is done, check Err() after the iteration.
+// A nil ctx is treated as context.Background().
+//
+// Returns nil and error if a is no CIDR or newBits is shorter than the prefix length of a
+// or out of range for the IP version.
+func (a Block) Subnets(ctx context.Context, newBits int) (*SubnetIter, error) {
+	ones, ok := a.PrefixLen()
+	if !ok {
+		return nil, errNoCIDR
+	}
+
+	maxBits := a.Base.bitLen()
+	if newBits < ones || newBits > maxBits {
+		return nil, errInvalidPrefixLen
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return &SubnetIter{
+		ctx:      ctx,
+		block:    a,
+		next:     a.Base,
+		newBits:  newBits,
+		hostBits: maxBits - newBits,
+	}, nil
+}
+
+// Next returns the next subnet and true, or Block{} and false if the iteration is exhausted
+// or the context is done.
+func (it *SubnetIter) Next() (Block, bool) {
+	if it.done {
+		return blockZero, false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		it.done = true
+		return blockZero, false
+	}
+
+	cidr, _ := NewCIDR(it.next, it.newBits)
+
+	var ok bool
+	it.next, ok = it.next.addPow2(it.hostBits)
+	if !ok || bytes.Compare(it.next[:], it.block.Last[:]) > 0 {
+		it.done = true
+	}
+
+	return cidr, true
+}
+
+// Err returns the context error if the iteration was stopped by the context, else nil.
+func (it *SubnetIter) Err() error {
+	return it.err
+}
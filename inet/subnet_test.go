@@ -0,0 +1,193 @@
+package inet
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestBlockSubnet(t *testing.T) {
+	tests := []struct {
+		in      string
+		newBits int
+		index   int64
+		want    string
+	}{
+		{"10.0.0.0/8", 8, 0, "10.0.0.0/8"},
+		{"10.0.0.0/8", 16, 0, "10.0.0.0/16"},
+		{"10.0.0.0/8", 16, 255, "10.255.0.0/16"},
+		{"10.0.0.0/8", 32, 1<<24 - 1, "10.255.255.255/32"},
+		{"192.168.0.0/22", 24, 3, "192.168.3.0/24"},
+		{"2001:db8:ab00::/48", 64, 4711, "2001:db8:ab00:1267::/64"},
+		{"2001:db8::/32", 128, 1, "2001:db8::1/128"},
+		{"::/0", 64, 1<<62 + 5, "4000:0:0:5::/64"},
+	}
+
+	for _, tt := range tests {
+		a := MustBlock(tt.in)
+		got, err := a.Subnet(tt.newBits, big.NewInt(tt.index))
+		if err != nil || got != MustBlock(tt.want) {
+			t.Errorf("(%s).Subnet(%d, %d) = (%v, %v), want %s", tt.in, tt.newBits, tt.index, got, err, tt.want)
+			continue
+		}
+
+		if got64, err := a.SubnetUint64(tt.newBits, uint64(tt.index)); err != nil || got64 != got {
+			t.Errorf("(%s).SubnetUint64(%d, %d) = (%v, %v), want %s", tt.in, tt.newBits, tt.index, got64, err, tt.want)
+		}
+
+		idx, err := a.SubnetIndex(got)
+		if err != nil || idx.Int64() != tt.index {
+			t.Errorf("(%s).SubnetIndex(%v) = (%v, %v), want %d", tt.in, got, idx, err, tt.index)
+		}
+	}
+
+	// the last /128 in ::/0, index beyond uint64
+	last := new(big.Int).Lsh(big.NewInt(1), 128)
+	last.Sub(last, big.NewInt(1))
+	got, err := MustBlock("::/0").Subnet(128, last)
+	if err != nil || got.Base != ipMaxV6 {
+		t.Errorf("(::/0).Subnet(128, 2^128-1) = (%v, %v), want %v/128", got, err, ipMaxV6)
+	}
+}
+
+func TestBlockSubnetFail(t *testing.T) {
+	tests := []struct {
+		in      string
+		newBits int
+		index   int64
+	}{
+		{"10.0.0.0/8", 7, 0},
+		{"10.0.0.0/8", 33, 0},
+		{"10.0.0.0/8", 16, 256},
+		{"10.0.0.0/8", 16, -1},
+		{"10.0.0.0/8", 8, 1},
+		{"10.0.0.1-10.0.0.2", 32, 0},
+	}
+
+	for _, tt := range tests {
+		if got, err := MustBlock(tt.in).Subnet(tt.newBits, big.NewInt(tt.index)); err == nil {
+			t.Errorf("(%s).Subnet(%d, %d) = %v, expected error", tt.in, tt.newBits, tt.index, got)
+		}
+	}
+
+	if got, err := MustBlock("10.0.0.0/8").Subnet(16, nil); err == nil {
+		t.Errorf("(10.0.0.0/8).Subnet(16, nil) = %v, expected error", got)
+	}
+	if got, err := MustBlock("10.0.0.0/8").SubnetUint64(16, 256); err == nil {
+		t.Errorf("(10.0.0.0/8).SubnetUint64(16, 256) = %v, expected error", got)
+	}
+
+	for _, tt := range []struct {
+		a, child string
+	}{
+		{"10.0.0.0/16", "10.1.0.0/24"},
+		{"10.0.0.0/16", "10.0.0.0/8"},
+		{"10.0.0.0/16", "10.0.0.1-10.0.0.2"},
+		{"10.0.0.0-10.0.0.2", "10.0.0.0/32"},
+		{"10.0.0.0/16", "::/128"},
+	} {
+		if idx, err := MustBlock(tt.a).SubnetIndex(MustBlock(tt.child)); err == nil {
+			t.Errorf("(%s).SubnetIndex(%s) = %v, expected error", tt.a, tt.child, idx)
+		}
+	}
+}
+
+func TestBlockSubnets(t *testing.T) {
+	tests := []struct {
+		in      string
+		newBits int
+		want    []string
+	}{
+		{"10.0.0.0/8", 8, []string{"10.0.0.0/8"}},
+		{"10.0.0.0/8", 10, []string{"10.0.0.0/10", "10.64.0.0/10", "10.128.0.0/10", "10.192.0.0/10"}},
+		{"255.255.255.252/30", 31, []string{"255.255.255.252/31", "255.255.255.254/31"}},
+		{"0.0.0.0/0", 1, []string{"0.0.0.0/1", "128.0.0.0/1"}},
+		{"2001:db8::/32", 34, []string{"2001:db8::/34", "2001:db8:4000::/34", "2001:db8:8000::/34", "2001:db8:c000::/34"}},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/126", 128, []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/128", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffd/128", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/128", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"}},
+	}
+
+	for _, tt := range tests {
+		it, err := MustBlock(tt.in).Subnets(context.Background(), tt.newBits)
+		if err != nil {
+			t.Errorf("(%s).Subnets(%d), got error %s", tt.in, tt.newBits, err)
+			continue
+		}
+
+		var got []string
+		for b, ok := it.Next(); ok; b, ok = it.Next() {
+			got = append(got, b.String())
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("(%s).Subnets(%d), got %v, want %v", tt.in, tt.newBits, got, tt.want)
+		}
+		if it.Err() != nil {
+			t.Errorf("(%s).Subnets(%d), got Err() %v", tt.in, tt.newBits, it.Err())
+		}
+	}
+
+	for _, tt := range []struct {
+		in      string
+		newBits int
+	}{
+		{"10.0.0.0/8", 7},
+		{"10.0.0.0/8", 33},
+		{"10.0.0.1-10.0.0.2", 32},
+	} {
+		if _, err := MustBlock(tt.in).Subnets(context.Background(), tt.newBits); err == nil {
+			t.Errorf("(%s).Subnets(%d), expected error", tt.in, tt.newBits)
+		}
+	}
+}
+
+func TestBlockSubnetsHuge(t *testing.T) {
+	// 2^112 subnets, just take some and compare with Subnet by index
+	a := MustBlock("2001::/16")
+	it, _ := a.Subnets(context.Background(), 128)
+
+	for i := int64(0); i < 1000; i++ {
+		b, ok := it.Next()
+		want, _ := a.Subnet(128, big.NewInt(i))
+		if !ok || b != want {
+			t.Fatalf("(%s).Subnets(128), got %v at index %d, want %v", a, b, i, want)
+		}
+	}
+}
+
+func TestBlockSubnetsCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	it, _ := MustBlock("2001:db8::/32").Subnets(ctx, 64)
+
+	n := 0
+	for _, ok := it.Next(); ok; _, ok = it.Next() {
+		n++
+		if n == 10 {
+			cancel()
+		}
+	}
+
+	if n != 10 {
+		t.Errorf("Subnets after cancel, got %d subnets, want 10", n)
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("Subnets after cancel, got Err() %v, want %v", it.Err(), context.Canceled)
+	}
+}
+
+func TestBlockSubnetsNilContext(t *testing.T) {
+	it, err := MustBlock("10.0.0.0/30").Subnets(nil, 32)
+	if err != nil {
+		t.Fatalf("Subnets(nil, 32), got error %s", err)
+	}
+
+	n := 0
+	for _, ok := it.Next(); ok; _, ok = it.Next() {
+		n++
+	}
+	if n != 4 || it.Err() != nil {
+		t.Errorf("Subnets(nil, 32), got %d subnets and Err() %v, want 4 and nil", n, it.Err())
+	}
+}
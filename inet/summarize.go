@@ -0,0 +1,191 @@
+package inet
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"sort"
+)
+
+var (
+	errInvalidBudget = errors.New("invalid prefix budget")
+	errOverBudget    = errors.New("prefix budget too small")
+)
+
+// Summarize reduces the blocks to at most k CIDRs, covering all the input blocks but maybe
+// some extra addresses. The number of extra addresses is minimized and returned as over,
+// it's zero if Aggregate returns not more than k CIDRs.
+//
+// Addresses in exclude are never covered as extra addresses, exclude may be nil.
+// Returns error for k < 1, invalid blocks or if the budget can't be met without covering exclude.
+//
+// The result is optimal, the runtime is O(n*k) for n aggregated input CIDRs.
+func Summarize(bs []Block, k int, exclude []Block) (cidrs []Block, over *big.Int, err error) {
+	if k < 1 {
+		return nil, nil, errInvalidBudget
+	}
+	for _, list := range [][]Block{bs, exclude} {
+		for _, b := range list {
+			if !b.IsValid() {
+				return nil, nil, errInvalidBlock
+			}
+		}
+	}
+
+	cidrs = Aggregate(bs)
+	if len(cidrs) <= k {
+		return cidrs, new(big.Int), nil
+	}
+
+	s := summarizer{
+		k:         k,
+		forbidden: Difference(exclude, cidrs),
+	}
+
+	// cidrs are sorted, IPv4 before IPv6
+	i := sort.Search(len(cidrs), func(i int) bool { return cidrs[i].Version() == 6 })
+
+	var root *sumNode
+	if i == 0 || i == len(cidrs) {
+		root = s.build(cidrs)
+	} else {
+		// IPv4 and IPv6 can't be covered by a common CIDR
+		root = s.join(s.build(cidrs[:i]), s.build(cidrs[i:]), nil)
+	}
+
+	j := len(root.cost)
+	if root.cost[j-1] == nil {
+		return nil, nil, errOverBudget
+	}
+
+	return root.collect(j, nil), root.cost[j-1], nil
+}
+
+// summarizer holds the parameters for building the summarization trie.
+type summarizer struct {
+	k         int
+	forbidden []Block // sorted ranges, not allowed as extra addresses
+}
+
+// sumNode is a node of the compressed binary trie over the aggregated CIDRs.
+// cost[j-1] holds the minimal number of extra addresses with at most j CIDRs, nil if not possible.
+// split[j-1] is 0 if the node is covered by its own CIDR, else the number of CIDRs for the left child.
+type sumNode struct {
+	cidr        Block
+	size        *big.Int // number of input addresses below node
+	left, right *sumNode
+	cost        []*big.Int
+	split       []int
+}
+
+// build returns the trie for the sorted, disjoint cidrs of the same IP version.
+func (s *summarizer) build(cidrs []Block) *sumNode {
+	if len(cidrs) == 1 {
+		return &sumNode{
+			cidr:  cidrs[0],
+			size:  cidrs[0].SizeBig(),
+			cost:  []*big.Int{new(big.Int)},
+			split: []int{0},
+		}
+	}
+
+	// smallest CIDR covering all cidrs, split at the middle
+	bits := commonPrefixLen(cidrs[0].Base, cidrs[len(cidrs)-1].Last)
+	cidr, _ := NewCIDR(cidrs[0].Base, bits)
+	mid, _ := cidr.Base.addPow2(cidr.Base.bitLen() - bits - 1)
+
+	i := sort.Search(len(cidrs), func(i int) bool { return bytes.Compare(cidrs[i].Base[:], mid[:]) >= 0 })
+	left, right := s.build(cidrs[:i]), s.build(cidrs[i:])
+
+	size := new(big.Int).Add(left.size, right.size)
+
+	var cover *big.Int
+	if !s.isForbidden(cidr) {
+		cover = new(big.Int).Sub(cidr.SizeBig(), size)
+	}
+
+	n := s.join(left, right, cover)
+	n.cidr = cidr
+	n.size = size
+	return n
+}
+
+// join returns the parent node of left and right, cover is the cost of covering the parent
+// with a single CIDR, nil if not possible.
+func (s *summarizer) join(left, right *sumNode, cover *big.Int) *sumNode {
+	nl, nr := len(left.cost), len(right.cost)
+
+	n := nl + nr
+	if n > s.k {
+		n = s.k
+	}
+
+	p := &sumNode{
+		left:  left,
+		right: right,
+		cost:  make([]*big.Int, n),
+		split: make([]int, n),
+	}
+
+	sum := new(big.Int)
+	for j := 1; j <= n; j++ {
+		best := cover
+
+		// j CIDRs split between left and right, at least one each
+		for a := max(1, j-nr); a <= min(nl, j-1); a++ {
+			l, r := left.cost[a-1], right.cost[j-a-1]
+			if l == nil || r == nil {
+				continue
+			}
+
+			sum.Add(l, r)
+			// swap, the former best is reused as buffer unless it's nil or shared
+			if best == nil || sum.Cmp(best) < 0 {
+				best, sum = sum, best
+				p.split[j-1] = a
+			}
+			if sum == nil || sum == cover {
+				sum = new(big.Int)
+			}
+		}
+		p.cost[j-1] = best
+	}
+
+	return p
+}
+
+// isForbidden reports whether cidr overlaps the forbidden ranges.
+func (s *summarizer) isForbidden(cidr Block) bool {
+	f := s.forbidden
+	i := sort.Search(len(f), func(i int) bool { return bytes.Compare(f[i].Last[:], cidr.Base[:]) >= 0 })
+	return i < len(f) && bytes.Compare(f[i].Base[:], cidr.Last[:]) <= 0
+}
+
+// collect appends the CIDRs of the best solution with at most j CIDRs, in sort order.
+func (n *sumNode) collect(j int, out []Block) []Block {
+	if j > len(n.cost) {
+		j = len(n.cost)
+	}
+
+	a := n.split[j-1]
+	if a == 0 {
+		return append(out, n.cidr)
+	}
+
+	out = n.left.collect(a, out)
+	return n.right.collect(j-a, out)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,153 @@
+package inet
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		in      []Block
+		k       int
+		exclude []Block
+		want    []Block
+		over    string
+		wantErr bool
+	}{
+		{in: blocks("10.0.0.0/24"), k: 0, wantErr: true},
+		{in: blocks("10.0.0.0/24"), k: 1, want: blocks("10.0.0.0/24"), over: "0"},
+		{in: blocks("10.0.0.0/25", "10.0.0.128/25"), k: 1, want: blocks("10.0.0.0/24"), over: "0"},
+		{in: blocks("10.0.0.0/25", "10.0.0.192/26"), k: 2, want: blocks("10.0.0.0/25", "10.0.0.192/26"), over: "0"},
+		{in: blocks("10.0.0.0/25", "10.0.0.192/26"), k: 1, want: blocks("10.0.0.0/24"), over: "64"},
+		{in: blocks("10.0.0.0", "10.0.0.2", "10.0.0.255"), k: 1, want: blocks("10.0.0.0/24"), over: "253"},
+		{in: blocks("10.0.0.0", "10.0.0.2", "10.0.0.255"), k: 2, want: blocks("10.0.0.0/30", "10.0.0.255/32"), over: "2"},
+		{in: blocks("10.0.0.0", "10.0.0.2", "10.0.0.255"), k: 2, exclude: blocks("10.0.0.128/25"), want: blocks("10.0.0.0/30", "10.0.0.255/32"), over: "2"},
+		{in: blocks("10.0.0.0", "10.0.0.2", "10.0.0.255"), k: 2, exclude: blocks("10.0.0.3"), wantErr: true},
+		{in: blocks("10.0.0.0", "10.0.0.2", "2001:db8::/64", "2001:db8:0:2::/64"), k: 2, want: blocks("10.0.0.0/30", "2001:db8::/62"), over: "36893488147419103234"},
+		{in: blocks("10.0.0.0", "2001:db8::"), k: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, over, err := Summarize(tt.in, tt.k, tt.exclude)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Summarize(%v, %d, %v), expected error, got %v", tt.in, tt.k, tt.exclude, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Summarize(%v, %d, %v), unexpected error: %v", tt.in, tt.k, tt.exclude, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) || over.String() != tt.over {
+			t.Errorf("Summarize(%v, %d, %v) = %v, %v, want %v, %v", tt.in, tt.k, tt.exclude, got, over, tt.want, tt.over)
+		}
+	}
+}
+
+func TestSummarizeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	base := MustIP("10.0.0.0")
+
+	randBlocks := func(n int) []Block {
+		var bs []Block
+		for ; n > 0; n-- {
+			lo := uint64(r.Intn(256))
+			hi := lo + uint64(r.Intn(4))
+			if hi > 255 {
+				hi = 255
+			}
+			bs = append(bs, newRange(base.AddUint64(lo), base.AddUint64(hi)))
+		}
+		return bs
+	}
+
+	for i := 0; i < 100; i++ {
+		in, exclude := randBlocks(1+r.Intn(20)), randBlocks(r.Intn(3))
+		k := 1 + r.Intn(8)
+
+		var inSet, exSet [256]bool
+		for j := uint64(0); j < 256; j++ {
+			ip := base.AddUint64(j)
+			inSet[j], exSet[j] = containsIP(in, ip), containsIP(exclude, ip)
+		}
+		want := bruteSummarize(&inSet, &exSet, 0, 256, k)
+
+		got, over, err := Summarize(in, k, exclude)
+		if want < 0 {
+			if err == nil {
+				t.Fatalf("Summarize(%v, %d, %v), expected error, got %v", in, k, exclude, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Summarize(%v, %d, %v), unexpected error: %v", in, k, exclude, err)
+		}
+
+		if over.Cmp(big.NewInt(int64(want))) != 0 {
+			t.Fatalf("Summarize(%v, %d, %v) = %v, over %v, want over %d", in, k, exclude, got, over, want)
+		}
+		if len(got) > k {
+			t.Fatalf("Summarize(%v, %d, %v) = %v, more than k CIDRs", in, k, exclude, got)
+		}
+
+		extra := 0
+		for j := uint64(0); j < 256; j++ {
+			covered := containsIP(got, base.AddUint64(j))
+			switch {
+			case inSet[j] && !covered:
+				t.Fatalf("Summarize(%v, %d, %v) = %v, %v not covered", in, k, exclude, got, base.AddUint64(j))
+			case !inSet[j] && covered && exSet[j]:
+				t.Fatalf("Summarize(%v, %d, %v) = %v, excluded %v covered", in, k, exclude, got, base.AddUint64(j))
+			case !inSet[j] && covered:
+				extra++
+			}
+		}
+		if extra != want {
+			t.Fatalf("Summarize(%v, %d, %v) = %v, covers %d extra addresses, want %d", in, k, exclude, got, extra, want)
+		}
+	}
+}
+
+// bruteSummarize returns the minimal extra addresses covering the input in the CIDR [lo, lo+size)
+// with at most k CIDRs, -1 if not possible. Exhaustive over the uncompressed trie.
+func bruteSummarize(in, ex *[256]bool, lo, size, k int) int {
+	extra, used, forbidden := 0, 0, false
+	for i := lo; i < lo+size; i++ {
+		switch {
+		case in[i]:
+			used++
+		case ex[i]:
+			forbidden = true
+		default:
+			extra++
+		}
+	}
+
+	if used == 0 {
+		return 0
+	}
+	if k == 0 {
+		return -1
+	}
+
+	best := -1
+	if !forbidden {
+		best = extra
+	}
+
+	if size > 1 {
+		half := size / 2
+		for a := 0; a <= k; a++ {
+			l := bruteSummarize(in, ex, lo, half, a)
+			r := bruteSummarize(in, ex, lo+half, half, k-a)
+			if l >= 0 && r >= 0 && (best < 0 || l+r < best) {
+				best = l + r
+			}
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,385 @@
+package inet
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	errInvalidTarget  = errors.New("invalid target expression")
+	errTooManyTargets = errors.New("target expression exceeds limit")
+)
+
+// targetExpr is the parsed form of a target expression,
+// for every octet (IPv4) or hextet (IPv6) a sorted list of disjunct value ranges.
+type targetExpr struct {
+	version int
+	fields  [][]valueRange // 4 octets or 8 hextets
+	block   Block          // plain block, if the expression is accepted by ParseBlock
+}
+
+// valueRange, lo and hi are inclusive
+type valueRange struct {
+	lo, hi uint32
+}
+
+// ParseTargets parses a target expression as used by network scanners and returns
+// the minimal list of CIDRs spanning the expression, see Aggregate.
+// Ranges, lists and wildcards are allowed in any octet or hextet, e.g.
+//
+//  10.0.0.1-254
+//  10.0.0-3.1-254
+//  192.168.1.*
+//  192.168.1,3,5.0-127
+//  2001:db8::1-ff
+//  2001:db8:0-f::*
+//
+// IPv6 hextets are hexadecimal, IPv4 octets decimal. Every input accepted by ParseBlock
+// is also a valid target expression.
+//
+// Returns error if the expression is invalid or expands to more than limit IP addresses.
+func ParseTargets(s string, limit uint64) ([]Block, error) {
+	e, err := parseTargetExpr(s, limit)
+	if err != nil {
+		return nil, err
+	}
+	return Aggregate(e.blocks()), nil
+}
+
+// TargetIter iterates lazily over the IP addresses of a target expression in ascending order.
+type TargetIter struct {
+	expr targetExpr
+	odo  *odometer // for octet or hextet ranges
+	next IP        // for plain blocks
+	done bool
+}
+
+// NewTargetIter parses the target expression s, see ParseTargets for the syntax,
+// and returns an iterator over all IP addresses of the expression.
+//
+// Returns error if the expression is invalid or expands to more than limit IP addresses.
+func NewTargetIter(s string, limit uint64) (*TargetIter, error) {
+	e, err := parseTargetExpr(s, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	it := &TargetIter{expr: e}
+	if e.fields == nil {
+		it.next = e.block.Base
+	} else {
+		it.odo = newOdometer(e.fields)
+	}
+	return it, nil
+}
+
+// Next returns the next IP address and true, or IP{} and false if the iteration is exhausted.
+func (it *TargetIter) Next() (IP, bool) {
+	if it.done {
+		return ipZero, false
+	}
+
+	// plain block
+	if it.odo == nil {
+		ip := it.next
+		if ip == it.expr.block.Last {
+			it.done = true
+		} else {
+			it.next = ip.AddUint64(1)
+		}
+		return ip, true
+	}
+
+	ip := ipFromFields(it.expr.version, it.odo.vals)
+	if !it.odo.inc(len(it.odo.vals)) {
+		it.done = true
+	}
+	return ip, true
+}
+
+// parseTargetExpr parses the expression and checks the size against limit.
+func parseTargetExpr(s string, limit uint64) (targetExpr, error) {
+	var e targetExpr
+
+	s = strings.TrimSpace(s)
+
+	if b, err := ParseBlock(s); err == nil {
+		e.version = b.Version()
+		e.block = b
+	} else {
+		e, err = parseTargetFields(s)
+		if err != nil {
+			return e, err
+		}
+	}
+
+	if e.size().Cmp(new(big.Int).SetUint64(limit)) > 0 {
+		return targetExpr{}, errTooManyTargets
+	}
+
+	return e, nil
+}
+
+// parseTargetFields splits s in octets or hextets and parses the value ranges.
+func parseTargetFields(s string) (targetExpr, error) {
+	var e targetExpr
+	var parts []string
+
+	if strings.IndexByte(s, ':') >= 0 {
+		e.version = 6
+
+		// expand "::" with the missing zero hextets
+		if i := strings.Index(s, "::"); i >= 0 {
+			head, tail := s[:i], s[i+2:]
+			if strings.Contains(tail, "::") {
+				return e, errInvalidTarget
+			}
+
+			var headParts, tailParts []string
+			if head != "" {
+				headParts = strings.Split(head, ":")
+			}
+			if tail != "" {
+				tailParts = strings.Split(tail, ":")
+			}
+
+			missing := 8 - len(headParts) - len(tailParts)
+			if missing < 1 {
+				return e, errInvalidTarget
+			}
+
+			parts = append(parts, headParts...)
+			for ; missing > 0; missing-- {
+				parts = append(parts, "0")
+			}
+			parts = append(parts, tailParts...)
+		} else {
+			parts = strings.Split(s, ":")
+		}
+
+		if len(parts) != 8 {
+			return e, errInvalidTarget
+		}
+	} else {
+		e.version = 4
+		parts = strings.Split(s, ".")
+		if len(parts) != 4 {
+			return e, errInvalidTarget
+		}
+	}
+
+	e.fields = make([][]valueRange, len(parts))
+	for i, p := range parts {
+		rs, err := parseTargetField(p, e.version)
+		if err != nil {
+			return e, err
+		}
+		e.fields[i] = rs
+	}
+
+	return e, nil
+}
+
+// parseTargetField parses a single octet or hextet:
+//
+//  *
+//  1-254
+//  1,3,5-7
+//
+// Returns the value ranges sorted and merged.
+func parseTargetField(s string, version int) ([]valueRange, error) {
+	base, max := 10, uint64(0xff)
+	if version == 6 {
+		base, max = 16, 0xffff
+	}
+
+	if s == "*" {
+		return []valueRange{{0, uint32(max)}}, nil
+	}
+
+	var rs []valueRange
+	for _, item := range strings.Split(s, ",") {
+		lo, hi := item, item
+		if i := strings.IndexByte(item, '-'); i >= 0 {
+			lo, hi = item[:i], item[i+1:]
+		}
+
+		l, err := parseTargetValue(lo, base, max)
+		if err != nil {
+			return nil, err
+		}
+		h, err := parseTargetValue(hi, base, max)
+		if err != nil {
+			return nil, err
+		}
+		if l > h {
+			return nil, errInvalidTarget
+		}
+		rs = append(rs, valueRange{uint32(l), uint32(h)})
+	}
+
+	// sort and merge overlapping or adjacent ranges
+	sort.Slice(rs, func(i, j int) bool { return rs[i].lo < rs[j].lo })
+
+	merged := rs[:1]
+	for _, r := range rs[1:] {
+		last := &merged[len(merged)-1]
+		if r.lo <= last.hi+1 {
+			if r.hi > last.hi {
+				last.hi = r.hi
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	return merged, nil
+}
+
+// parseTargetValue parses a decimal octet or a hexadecimal hextet, no sign allowed.
+func parseTargetValue(s string, base int, max uint64) (uint64, error) {
+	if s == "" || s[0] == '+' || s[0] == '-' {
+		return 0, errInvalidTarget
+	}
+	v, err := strconv.ParseUint(s, base, 32)
+	if err != nil || v > max {
+		return 0, errInvalidTarget
+	}
+	return v, nil
+}
+
+// size returns the number of IP addresses in the expression.
+func (e targetExpr) size() *big.Int {
+	if e.fields == nil {
+		return e.block.SizeBig()
+	}
+
+	n := big.NewInt(1)
+	for _, rs := range e.fields {
+		var c int64
+		for _, r := range rs {
+			c += int64(r.hi-r.lo) + 1
+		}
+		n.Mul(n, big.NewInt(c))
+	}
+	return n
+}
+
+// blocks returns the contiguous blocks of the expression in ascending order.
+//
+// Trailing fields spanning the whole value range are folded into the blocks,
+// 10.0-3.*.* generates 4 blocks and not 1024.
+func (e targetExpr) blocks() []Block {
+	if e.fields == nil {
+		return []Block{e.block}
+	}
+
+	max := uint32(0xff)
+	if e.version == 6 {
+		max = 0xffff
+	}
+
+	// t is the field generating the blocks, all fields behind t are full
+	t := len(e.fields) - 1
+	for t > 0 {
+		rs := e.fields[t]
+		if len(rs) != 1 || rs[0].lo != 0 || rs[0].hi != max {
+			break
+		}
+		t--
+	}
+
+	var out []Block
+	odo := newOdometer(e.fields)
+	for {
+		for _, r := range e.fields[t] {
+			vals := make([]uint32, len(e.fields))
+			copy(vals, odo.vals[:t])
+
+			vals[t] = r.lo
+			base := ipFromFields(e.version, vals)
+
+			vals[t] = r.hi
+			for i := t + 1; i < len(vals); i++ {
+				vals[i] = max
+			}
+			last := ipFromFields(e.version, vals)
+
+			b := Block{Base: base, Last: last}
+			b.Mask = b.getMask()
+			out = append(out, b)
+		}
+
+		if !odo.inc(t) {
+			break
+		}
+	}
+
+	return out
+}
+
+// ipFromFields makes an IP from octet or hextet values.
+func ipFromFields(version int, vals []uint32) IP {
+	if version == 4 {
+		bs := make([]byte, 4)
+		for i, v := range vals {
+			bs[i] = byte(v)
+		}
+		return setBytes(bs)
+	}
+
+	bs := make([]byte, 16)
+	for i, v := range vals {
+		bs[2*i] = byte(v >> 8)
+		bs[2*i+1] = byte(v)
+	}
+	return setBytes(bs)
+}
+
+// odometer counts over all values of the fields, the last field is the fastest.
+type odometer struct {
+	fields [][]valueRange
+	idx    []int    // index of current range in field
+	vals   []uint32 // current value in field
+}
+
+func newOdometer(fields [][]valueRange) *odometer {
+	o := &odometer{
+		fields: fields,
+		idx:    make([]int, len(fields)),
+		vals:   make([]uint32, len(fields)),
+	}
+	for i, rs := range fields {
+		o.vals[i] = rs[0].lo
+	}
+	return o
+}
+
+// inc increments the odometer in the first n fields, fields behind n are untouched.
+// Returns false on wrap around.
+func (o *odometer) inc(n int) bool {
+	for i := n - 1; i >= 0; i-- {
+		rs := o.fields[i]
+
+		// next value in current range
+		if o.vals[i] < rs[o.idx[i]].hi {
+			o.vals[i]++
+			return true
+		}
+
+		// next range in field
+		if o.idx[i] < len(rs)-1 {
+			o.idx[i]++
+			o.vals[i] = rs[o.idx[i]].lo
+			return true
+		}
+
+		// wrap around, carry to next field
+		o.idx[i] = 0
+		o.vals[i] = rs[0].lo
+	}
+	return false
+}
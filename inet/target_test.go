@@ -0,0 +1,132 @@
+package inet
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseTargets(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"10.0.0.1-254", []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8/29", "10.0.0.16/28", "10.0.0.32/27", "10.0.0.64/26", "10.0.0.128/26", "10.0.0.192/27", "10.0.0.224/28", "10.0.0.240/29", "10.0.0.248/30", "10.0.0.252/31", "10.0.0.254/32"}},
+		{"10.0.0-3.*", []string{"10.0.0.0/22"}},
+		{"10.0-3.*.*", []string{"10.0.0.0/14"}},
+		{"192.168.1.*", []string{"192.168.1.0/24"}},
+		{"192.168.1,3.0-127", []string{"192.168.1.0/25", "192.168.3.0/25"}},
+		{"192.168.1,2.0-255", []string{"192.168.1.0/24", "192.168.2.0/24"}},
+		{"10.0.0-1.0,1", []string{"10.0.0.0/31", "10.0.1.0/31"}},
+		{"10.0.0.0,1,2,3", []string{"10.0.0.0/30"}},
+		{"*.*.*.*", []string{"0.0.0.0/0"}},
+		{"2001:db8::1-ff", []string{"2001:db8::1/128", "2001:db8::2/127", "2001:db8::4/126", "2001:db8::8/125", "2001:db8::10/124", "2001:db8::20/123", "2001:db8::40/122", "2001:db8::80/121"}},
+		{"2001:db8:0-1::*", []string{"2001:db8::/112", "2001:db8:1::/112"}},
+		{"2001:db8::0-f:*", []string{"2001:db8::/108"}},
+		{"10.0.0.0/30", []string{"10.0.0.0/30"}},
+		{"10.0.0.5-10.0.0.6", []string{"10.0.0.5/32", "10.0.0.6/32"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTargets(tt.in, math.MaxUint64)
+		if err != nil {
+			t.Errorf("ParseTargets(%q), got error %s", tt.in, err)
+			continue
+		}
+
+		var want []Block
+		for _, s := range tt.want {
+			want = append(want, MustBlock(s))
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseTargets(%q) = %v, want %v", tt.in, got, want)
+		}
+	}
+}
+
+func TestParseTargetsFail(t *testing.T) {
+	tests := []string{
+		"",
+		"10.0.0",
+		"10.0.0.1-",
+		"10.0.0.-1",
+		"10.0.0.254-1",
+		"10.0.0.256",
+		"10.0.0.1-256",
+		"10.0.0.+1",
+		"10.0.0.1,,2",
+		"10.0.0.0.1",
+		"10.0.0.**",
+		"2001:db8::1-fffff",
+		"2001:db8::1::2",
+		"2001:db8:1:2:3:4:5:6:7",
+		"2001:db8:1:2:3:4:5:6::1-2",
+		"2001:dx8::1-2",
+	}
+
+	for _, in := range tests {
+		if _, err := ParseTargets(in, math.MaxUint64); err == nil {
+			t.Errorf("success for ParseTargets(%q) is not expected!", in)
+		}
+	}
+}
+
+func TestParseTargetsLimit(t *testing.T) {
+	tests := []struct {
+		in    string
+		limit uint64
+		ok    bool
+	}{
+		{"10.0.0.1-254", 254, true},
+		{"10.0.0.1-254", 253, false},
+		{"10.0.0-3.*", 1024, true},
+		{"10.0.0-3.*", 1023, false},
+		{"10.0.0.0/24", 256, true},
+		{"10.0.0.0/24", 255, false},
+		{"2001:db8::/64", math.MaxUint64, false},
+		{"2001:db8:*:*:*:*:*:*", math.MaxUint64, false},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseTargets(tt.in, tt.limit)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseTargets(%q, %d), got error %v, want ok %v", tt.in, tt.limit, err, tt.ok)
+		}
+	}
+}
+
+func TestTargetIter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"10.0.0.1-3", []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}},
+		{"10.0.0-1.254,255", []string{"10.0.0.254", "10.0.0.255", "10.0.1.254", "10.0.1.255"}},
+		{"10.0.0.7,5,6", []string{"10.0.0.5", "10.0.0.6", "10.0.0.7"}},
+		{"2001:db8::fe-101", []string{"2001:db8::fe", "2001:db8::ff", "2001:db8::100", "2001:db8::101"}},
+		{"10.0.0.254/31", []string{"10.0.0.254", "10.0.0.255"}},
+		{"255.255.255.255", []string{"255.255.255.255"}},
+	}
+
+	for _, tt := range tests {
+		it, err := NewTargetIter(tt.in, 1000)
+		if err != nil {
+			t.Errorf("NewTargetIter(%q), got error %s", tt.in, err)
+			continue
+		}
+
+		var got []string
+		for ip, ok := it.Next(); ok; ip, ok = it.Next() {
+			got = append(got, ip.String())
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("NewTargetIter(%q), got %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	if _, err := NewTargetIter("10.0.*.*", 1000); err != errTooManyTargets {
+		t.Errorf("NewTargetIter, got %v, want %v", err, errTooManyTargets)
+	}
+}
@@ -0,0 +1,47 @@
+package inet
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// Uint128 is an unsigned 128 bit integer, big enough for the number of addresses
+// in all IPv6 blocks except ::/0.
+type Uint128 struct {
+	Hi, Lo uint64
+}
+
+// Big returns u as big.Int.
+func (u Uint128) Big() *big.Int {
+	x := new(big.Int).SetUint64(u.Hi)
+	x.Lsh(x, 64)
+	return x.Or(x, new(big.Int).SetUint64(u.Lo))
+}
+
+// String returns u in decimal notation.
+func (u Uint128) String() string {
+	return u.Big().String()
+}
+
+// uint128FromIP returns the address of ip as integer, without the version.
+func uint128FromIP(ip IP) Uint128 {
+	if ip.Version() == 4 {
+		return Uint128{Lo: uint64(binary.BigEndian.Uint32(ip[1:5]))}
+	}
+	return Uint128{Hi: binary.BigEndian.Uint64(ip[1:9]), Lo: binary.BigEndian.Uint64(ip[9:17])}
+}
+
+// add returns u+v, the bool is false on overflow.
+func (u Uint128) add(v Uint128) (Uint128, bool) {
+	lo, carry := bits.Add64(u.Lo, v.Lo, 0)
+	hi, carry := bits.Add64(u.Hi, v.Hi, carry)
+	return Uint128{Hi: hi, Lo: lo}, carry == 0
+}
+
+// sub returns u-v, the bool is false on underflow.
+func (u Uint128) sub(v Uint128) (Uint128, bool) {
+	lo, borrow := bits.Sub64(u.Lo, v.Lo, 0)
+	hi, borrow := bits.Sub64(u.Hi, v.Hi, borrow)
+	return Uint128{Hi: hi, Lo: lo}, borrow == 0
+}
@@ -0,0 +1,80 @@
+package inet
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBlockSizeNumeric(t *testing.T) {
+	tests := []struct {
+		in     string
+		size   string
+		ok64   bool
+		ok128  bool
+		size64 uint64
+	}{
+		{"10.0.0.1", "1", true, true, 1},
+		{"10.0.0.0-10.0.0.43", "44", true, true, 44},
+		{"0.0.0.0/0", "4294967296", true, true, 1 << 32},
+		{"2001:db8::/64", "18446744073709551616", false, true, 0},
+		{"2001:db8::/65", "9223372036854775808", true, true, 1 << 63},
+		{"::-::ffff:ffff:ffff:ffff", "18446744073709551616", false, true, 0},
+		{"::/1", "170141183460469231731687303715884105728", false, true, 0},
+		{"::/0", "340282366920938463463374607431768211456", false, false, 0},
+	}
+
+	for _, tt := range tests {
+		a := MustBlock(tt.in)
+
+		if got := a.SizeBig().String(); got != tt.size {
+			t.Errorf("(%s).SizeBig() = %s, want %s", tt.in, got, tt.size)
+		}
+		if got := a.Size(); got != tt.size {
+			t.Errorf("(%s).Size() = %s, want %s", tt.in, got, tt.size)
+		}
+
+		n128, ok := a.SizeUint128()
+		if ok != tt.ok128 || (ok && n128.String() != tt.size) {
+			t.Errorf("(%s).SizeUint128() = (%v, %v), want (%s, %v)", tt.in, n128, ok, tt.size, tt.ok128)
+		}
+
+		n64, ok := a.SizeUint64()
+		if ok != tt.ok64 || n64 != tt.size64 {
+			t.Errorf("(%s).SizeUint64() = (%v, %v), want (%d, %v)", tt.in, n64, ok, tt.size64, tt.ok64)
+		}
+	}
+}
+
+func TestTotalSize(t *testing.T) {
+	tests := []struct {
+		bs   []Block
+		want string
+	}{
+		{nil, "0"},
+		{blocks("10.0.0.0/24", "10.0.0.0/25", "10.0.0.128-10.0.1.3"), "260"},
+		{blocks("0.0.0.0/0", "::/0", "10.0.0.0/8"), "340282366920938463463374607436063178752"},
+	}
+
+	for _, tt := range tests {
+		if got := TotalSize(tt.bs).String(); got != tt.want {
+			t.Errorf("TotalSize(%v) = %s, want %s", tt.bs, got, tt.want)
+		}
+	}
+}
+
+func TestUint128Big(t *testing.T) {
+	u := Uint128{Hi: 1, Lo: 2}
+	want := new(big.Int).Lsh(big.NewInt(1), 64)
+	want.Add(want, big.NewInt(2))
+
+	if u.Big().Cmp(want) != 0 {
+		t.Errorf("Uint128{1, 2}.Big() = %v, want %v", u.Big(), want)
+	}
+
+	if _, ok := (Uint128{Hi: ^uint64(0), Lo: ^uint64(0)}).add(Uint128{Lo: 1}); ok {
+		t.Errorf("Uint128 add, overflow not detected")
+	}
+	if _, ok := (Uint128{}).sub(Uint128{Lo: 1}); ok {
+		t.Errorf("Uint128 sub, underflow not detected")
+	}
+}
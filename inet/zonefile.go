@@ -0,0 +1,199 @@
+package inet
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	errNoHostName = errors.New("no host name template or callback")
+	errZoneLimit  = errors.New("zone record limit reached")
+)
+
+// PTRZoneOptions for WritePTRZone.
+type PTRZoneOptions struct {
+	// Name returns the host name for ip, e.g. "host-1.example.net.", takes precedence over Template.
+	Name func(ip IP) string
+
+	// Template for the host name, with the placeholders
+	//
+	//  {ip}        the IP address, dots and colons replaced by dashes, e.g. 192-0-2-1,
+	//              IPv6 is expanded, "::" would give labels like "2001-db8--" or "--1"
+	//  {expanded}  the expanded IP address, e.g. 192-000-002-001 or 2001-0db8-0000-...-0001
+	//
+	// e.g. "host-{expanded}.example.net."
+	Template string
+
+	// SOA record data, default is a placeholder to be edited.
+	SOA string
+
+	// NS is the name server of the zone, default is a placeholder to be edited.
+	NS string
+
+	// TTL for the records in seconds, default is 3600.
+	TTL uint32
+
+	// Limit is the max number of PTR records, 0 means no limit.
+	// Use it to bound the output for huge IPv6 blocks.
+	Limit uint64
+
+	// Forward, if not nil, gets the matching A or AAAA records.
+	Forward io.Writer
+}
+
+// defaultSOA and defaultNS are placeholders, the name servers, mail and serial must be edited.
+const (
+	defaultSOA = "ns.invalid. hostmaster.invalid. ( 1 3600 900 604800 3600 ) ; placeholder"
+	defaultNS  = "ns.invalid. ; placeholder"
+)
+
+// WritePTRZone writes a reverse DNS zone in BIND format with the PTR records for all addresses
+// of the block to w, streaming, the addresses are not materialized.
+//
+// The zone is the smallest zone containing the block, octet (IPv4) or nibble (IPv6) aligned,
+// or the RFC 2317 classless zone for IPv4 CIDRs longer than /24, see ReverseZones.
+// IPv4 ranges within a /24 are written to the parent /24 zone, a /32 or /128 gets the
+// zone of the host with the PTR record at the apex, e.g.
+//
+//  $ORIGIN 2.0.192.in-addr.arpa.
+//  $TTL 3600
+//  @  IN  SOA  ns.invalid. hostmaster.invalid. ( 1 3600 900 604800 3600 ) ; placeholder
+//  @  IN  NS   ns.invalid. ; placeholder
+//  1  IN  PTR  host-192-000-002-001.example.net.
+//  ...
+//
+// Host names without trailing dot are made absolute. Returns error if no host name
+// Template or Name callback is given, on write errors or if the Limit is reached before
+// all addresses are written.
+func WritePTRZone(w io.Writer, b Block, opts PTRZoneOptions) error {
+	if !b.IsValid() {
+		return errInvalidBlock
+	}
+
+	name := opts.Name
+	if name == nil {
+		if opts.Template == "" {
+			return errNoHostName
+		}
+		name = func(ip IP) string { return expandHostTemplate(opts.Template, ip) }
+	}
+
+	soa := opts.SOA
+	if soa == "" {
+		soa = defaultSOA
+	}
+
+	ns := opts.NS
+	if ns == "" {
+		ns = defaultNS
+	}
+
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = 3600
+	}
+
+	zone, labels := b.ptrZone()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "$ORIGIN %s.\n", zone)
+	fmt.Fprintf(bw, "$TTL %d\n", ttl)
+	fmt.Fprintf(bw, "@\tIN\tSOA\t%s\n", soa)
+	fmt.Fprintf(bw, "@\tIN\tNS\t%s\n", ns)
+
+	var fw *bufio.Writer
+	if opts.Forward != nil {
+		fw = bufio.NewWriter(opts.Forward)
+	}
+
+	rr := "A"
+	if b.Version() == 6 {
+		rr = "AAAA"
+	}
+
+	var err error
+	var n uint64
+
+	it := b.Iter(nil)
+	for ip, ok := it.Next(); ok; ip, ok = it.Next() {
+		if opts.Limit != 0 && n == opts.Limit {
+			err = errZoneLimit
+			break
+		}
+		n++
+
+		host := name(ip)
+		if !strings.HasSuffix(host, ".") {
+			host += "."
+		}
+
+		// owner relative to $ORIGIN
+		rev := strings.Split(ip.Reverse(), ".")
+		owner := strings.Join(rev[:len(rev)-labels], ".")
+		if owner == "" {
+			owner = "@"
+		}
+
+		if _, err = fmt.Fprintf(bw, "%s\tIN\tPTR\t%s\n", owner, host); err != nil {
+			return err
+		}
+
+		if fw != nil {
+			if _, err = fmt.Fprintf(fw, "%s\tIN\t%s\t%s\n", host, rr, ip); err != nil {
+				return err
+			}
+		}
+	}
+
+	if fw != nil {
+		if ferr := fw.Flush(); ferr != nil {
+			return ferr
+		}
+	}
+	if ferr := bw.Flush(); ferr != nil {
+		return ferr
+	}
+	return err
+}
+
+// ptrZone returns the name of the smallest reverse zone containing the block and
+// the number of labels of the parent reverse name. The RFC 2317 classless zone is
+// only used for CIDRs, a range gets the octet aligned parent zone.
+func (a Block) ptrZone() (string, int) {
+	cidr, _ := CoveringCIDR([]Block{a})
+	bits, _ := cidr.PrefixLen()
+
+	if cidr.Version() == 4 {
+		if bits == 32 && a.IsCIDR() {
+			return reverseName(cidr.Base, 4), 4
+		}
+		if bits > 24 {
+			if a.IsCIDR() {
+				return cidr.classlessZone(bits), 3
+			}
+			bits = 24
+		}
+		return reverseName(cidr.Base, bits/8), bits / 8
+	}
+	return reverseName(cidr.Base, bits/4), bits / 4
+}
+
+// expandHostTemplate replaces the placeholders in the host name template.
+func expandHostTemplate(tmpl string, ip IP) string {
+	dashed := strings.NewReplacer(".", "-", ":", "-")
+
+	// no "::" in host names, labels must not start or end with a dash
+	s := ip.String()
+	if ip.Version() == 6 {
+		s = ip.Expand()
+	}
+
+	r := strings.NewReplacer(
+		"{ip}", dashed.Replace(s),
+		"{expanded}", dashed.Replace(ip.Expand()),
+	)
+	return r.Replace(tmpl)
+}
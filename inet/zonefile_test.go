@@ -0,0 +1,161 @@
+package inet
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWritePTRZone(t *testing.T) {
+	var zone, fwd bytes.Buffer
+	err := WritePTRZone(&zone, MustBlock("192.0.2.0/30"), PTRZoneOptions{
+		Template: "host-{expanded}.example.net",
+		Forward:  &fwd,
+	})
+	if err != nil {
+		t.Fatalf("WritePTRZone(), unexpected error: %v", err)
+	}
+
+	want := `$ORIGIN 0/30.2.0.192.in-addr.arpa.
+$TTL 3600
+@	IN	SOA	ns.invalid. hostmaster.invalid. ( 1 3600 900 604800 3600 ) ; placeholder
+@	IN	NS	ns.invalid. ; placeholder
+0	IN	PTR	host-192-000-002-000.example.net.
+1	IN	PTR	host-192-000-002-001.example.net.
+2	IN	PTR	host-192-000-002-002.example.net.
+3	IN	PTR	host-192-000-002-003.example.net.
+`
+	if got := zone.String(); got != want {
+		t.Errorf("WritePTRZone(), got\n%s\nwant\n%s", got, want)
+	}
+
+	wantFwd := `host-192-000-002-000.example.net.	IN	A	192.0.2.0
+host-192-000-002-001.example.net.	IN	A	192.0.2.1
+host-192-000-002-002.example.net.	IN	A	192.0.2.2
+host-192-000-002-003.example.net.	IN	A	192.0.2.3
+`
+	if got := fwd.String(); got != wantFwd {
+		t.Errorf("WritePTRZone(), forward got\n%s\nwant\n%s", got, wantFwd)
+	}
+}
+
+func TestWritePTRZoneV6(t *testing.T) {
+	var zone, fwd bytes.Buffer
+	err := WritePTRZone(&zone, MustBlock("2001:db8::/64"), PTRZoneOptions{
+		Name:    func(ip IP) string { return "h" + strconv.Itoa(int(ip.Bytes()[15])) },
+		SOA:     "ns1.example.net. hostmaster.example.net. ( 42 3600 900 604800 300 )",
+		NS:      "ns1.example.net.",
+		TTL:     300,
+		Limit:   2,
+		Forward: &fwd,
+	})
+	if err == nil {
+		t.Errorf("WritePTRZone(), expected limit error")
+	}
+
+	want := `$ORIGIN 0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.
+$TTL 300
+@	IN	SOA	ns1.example.net. hostmaster.example.net. ( 42 3600 900 604800 300 )
+@	IN	NS	ns1.example.net.
+0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0	IN	PTR	h0.
+1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0	IN	PTR	h1.
+`
+	if got := zone.String(); got != want {
+		t.Errorf("WritePTRZone(), got\n%s\nwant\n%s", got, want)
+	}
+
+	if got := strings.Count(fwd.String(), "\tIN\tAAAA\t"); got != 2 {
+		t.Errorf("WritePTRZone(), got %d AAAA records, want 2", got)
+	}
+}
+
+func TestWritePTRZoneRange(t *testing.T) {
+	var zone bytes.Buffer
+	err := WritePTRZone(&zone, MustBlock("192.0.2.5-192.0.2.6"), PTRZoneOptions{Template: "h-{ip}"})
+	if err != nil {
+		t.Fatalf("WritePTRZone(), unexpected error: %v", err)
+	}
+
+	want := `$ORIGIN 2.0.192.in-addr.arpa.
+$TTL 3600
+@	IN	SOA	ns.invalid. hostmaster.invalid. ( 1 3600 900 604800 3600 ) ; placeholder
+@	IN	NS	ns.invalid. ; placeholder
+5	IN	PTR	h-192-0-2-5.
+6	IN	PTR	h-192-0-2-6.
+`
+	if got := zone.String(); got != want {
+		t.Errorf("WritePTRZone(), got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWritePTRZoneHost(t *testing.T) {
+	tests := []struct {
+		block string
+		want  string
+	}{
+		{"10.0.0.1/32", `$ORIGIN 1.0.0.10.in-addr.arpa.
+$TTL 3600
+@	IN	SOA	ns.invalid. hostmaster.invalid. ( 1 3600 900 604800 3600 ) ; placeholder
+@	IN	NS	ns.invalid. ; placeholder
+@	IN	PTR	h-10-0-0-1.
+`},
+		{"2001:db8::/128", `$ORIGIN 0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.
+$TTL 3600
+@	IN	SOA	ns.invalid. hostmaster.invalid. ( 1 3600 900 604800 3600 ) ; placeholder
+@	IN	NS	ns.invalid. ; placeholder
+@	IN	PTR	h-2001-0db8-0000-0000-0000-0000-0000-0000.
+`},
+	}
+
+	for _, tt := range tests {
+		var zone bytes.Buffer
+		if err := WritePTRZone(&zone, MustBlock(tt.block), PTRZoneOptions{Template: "h-{ip}"}); err != nil {
+			t.Fatalf("WritePTRZone(%s), unexpected error: %v", tt.block, err)
+		}
+		if got := zone.String(); got != tt.want {
+			t.Errorf("WritePTRZone(%s), got\n%s\nwant\n%s", tt.block, got, tt.want)
+		}
+	}
+}
+
+func TestWritePTRZoneErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePTRZone(&buf, MustBlock("10.0.0.0/24"), PTRZoneOptions{}); err == nil {
+		t.Errorf("WritePTRZone() without host names, expected error")
+	}
+	if err := WritePTRZone(&buf, Block{}, PTRZoneOptions{Template: "{ip}"}); err == nil {
+		t.Errorf("WritePTRZone(Block{}), expected error")
+	}
+
+	werr := errors.New("write failed")
+	if err := WritePTRZone(failWriter{werr}, MustBlock("10.0.0.0/16"), PTRZoneOptions{Template: "{ip}"}); err != werr {
+		t.Errorf("WritePTRZone() on failing writer, got %v, want %v", err, werr)
+	}
+}
+
+type failWriter struct{ err error }
+
+func (w failWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func TestExpandHostTemplate(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		ip   string
+		want string
+	}{
+		{"host-{ip}.example.net.", "192.0.2.1", "host-192-0-2-1.example.net."},
+		{"{expanded}.example.net.", "192.0.2.1", "192-000-002-001.example.net."},
+		{"v6-{ip}.example.net.", "2001:db8::1", "v6-2001-0db8-0000-0000-0000-0000-0000-0001.example.net."},
+		{"v6-{ip}.example.net.", "2001:db8::", "v6-2001-0db8-0000-0000-0000-0000-0000-0000.example.net."},
+		{"{ip}.example.net.", "::1", "0000-0000-0000-0000-0000-0000-0000-0001.example.net."},
+		{"{expanded}", "2001:db8::1", "2001-0db8-0000-0000-0000-0000-0000-0001"},
+	}
+
+	for _, tt := range tests {
+		if got := expandHostTemplate(tt.tmpl, MustIP(tt.ip)); got != tt.want {
+			t.Errorf("expandHostTemplate(%q, %v) = %q, want %q", tt.tmpl, tt.ip, got, tt.want)
+		}
+	}
+}
@@ -194,3 +194,38 @@ func GenRangeMixed(n int) []inet.Block {
 
 	return rs
 }
+
+// GenSiblingV4 returns v4 CIDRs like in routing tables and blocklists, mostly
+// adjacent and disjoint /24 siblings, some split in /25 halves or with a contained /26,
+// starting at 10.0.0.0
+func GenSiblingV4(n int) []inet.Block {
+	var r = rand.New(rand.NewSource(int64(n)))
+	out := make([]inet.Block, 0, n+1)
+
+	buf := make([]byte, 4)
+	for cursor := uint32(10 << 24); len(out) < n; cursor += 256 {
+		binary.BigEndian.PutUint32(buf, cursor)
+		ip, _ := inet.ParseIP(buf)
+
+		switch r.Intn(4) {
+		case 0:
+			// gap
+		case 1:
+			b, _ := inet.ParseBlock(fmt.Sprintf("%s/24", ip))
+			out = append(out, b)
+		case 2:
+			b1, _ := inet.ParseBlock(fmt.Sprintf("%s/25", ip))
+			b2, _ := inet.ParseBlock(fmt.Sprintf("%s/25", ip.AddUint64(128)))
+			out = append(out, b1, b2)
+		case 3:
+			b1, _ := inet.ParseBlock(fmt.Sprintf("%s/24", ip))
+			b2, _ := inet.ParseBlock(fmt.Sprintf("%s/26", ip.AddUint64(64)))
+			out = append(out, b1, b2)
+		}
+	}
+
+	out = out[:n]
+	r.Shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] })
+
+	return out
+}
@@ -147,3 +147,33 @@ func BenchmarkTreeRemoveBranch(b *testing.B) {
 
 	}
 }
+
+func BenchmarkMemoryTree(b *testing.B) {
+	bs := internal.GenBlockMixed(1000000)
+	is := make([]tree.Item, len(bs))
+	for i := range bs {
+		is[i] = tree.Item{Block: bs[i]}
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		t := tree.New()
+		_ = t.Insert(is...)
+	}
+}
+
+func BenchmarkMemoryCompactTree(b *testing.B) {
+	bs := internal.GenBlockMixed(1000000)
+	is := make([]tree.CompactItem, len(bs))
+	for i := range bs {
+		is[i] = tree.CompactItem{Block: bs[i].Compact()}
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		t := tree.NewCompact()
+		_ = t.Insert(is...)
+	}
+}
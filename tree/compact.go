@@ -0,0 +1,226 @@
+package tree
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gaissmai/go-inet/inet"
+)
+
+// CompactTree is the memory saving variant of Tree for huge tables, with the same algorithms.
+// The items hold an inet.CompactBlock instead of an inet.Block and are stored inline in the nodes,
+// there is no parent link and no separate allocation per item.
+type CompactTree struct {
+	// the entry point of the tree, root has no item
+	root compactNode
+}
+
+// CompactItem in the CompactTree, maybe with additional payload.
+type CompactItem struct {
+	Block   inet.CompactBlock // Block.Contains() and Block.Compare() define the position in the tree.
+	Payload interface{}       // payload for this tree item
+}
+
+// String returns the string for the compact block, don't know how to render the payload.
+func (item CompactItem) String() string {
+	return item.Block.String()
+}
+
+// compactNode in the CompactTree, recursive data structure.
+type compactNode struct {
+	item   CompactItem
+	childs []*compactNode
+}
+
+// NewCompact allocates a new compact tree and returns the pointer.
+func NewCompact() *CompactTree {
+	return &CompactTree{}
+}
+
+// Insert item(s) into the tree. Inserting a bulk of items is much faster
+// than inserting unsorted single items in a loop.
+//
+// Returns error on duplicate items in the tree.
+func (t *CompactTree) Insert(items ...CompactItem) error {
+
+	if len(items) > 1 {
+		// sort before insert makes insertion much faster, no or less parent-child-relinking needed.
+		sort.Slice(items, func(i, j int) bool { return items[i].Block.Compare(items[j].Block) < 0 })
+	}
+
+	for i := range items {
+		if err := t.root.insertNode(&compactNode{item: items[i]}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Remove one item from tree, relink parent/child relation at the gap. Returns error if not found.
+func (t *CompactTree) Remove(item CompactItem) error {
+	return t.root.remove(item)
+}
+
+// Contains reports whether the item is contained in any element of the tree.
+func (t *CompactTree) Contains(item CompactItem) bool {
+	// just look in root childs, see Tree.Contains
+	childs := t.root.childs
+	idx := t.root.search(item.Block)
+
+	if idx < len(childs) && childs[idx].item.Block.Compare(item.Block) == 0 {
+		return true
+	}
+	return idx > 0 && childs[idx-1].item.Block.Contains(item.Block)
+}
+
+// Lookup item for longest prefix match in the tree.
+// If not found, returns input argument and false.
+func (t *CompactTree) Lookup(item CompactItem) (CompactItem, bool) {
+	node := &t.root
+
+	for {
+		idx := node.search(item.Block)
+
+		// found by exact match?
+		if idx < len(node.childs) && node.childs[idx].item.Block.Compare(item.Block) == 0 {
+			return node.childs[idx].item, true
+		}
+
+		// descent into the child before idx, if it contains the item
+		if idx > 0 && node.childs[idx-1].item.Block.Contains(item.Block) {
+			node = node.childs[idx-1]
+			continue
+		}
+
+		// no child contains item, we are still at the root node
+		if node == &t.root {
+			return item, false
+		}
+
+		// this node is the end of descent, return longest prefix match
+		return node.item, true
+	}
+}
+
+// CompactWalkFunc is the type of the function called for each item visited by CompactTree.Walk().
+// The arguments are the current item and the depth, starting with 0.
+type CompactWalkFunc func(item CompactItem, depth int) error
+
+// Walk the tree in depth first order, calling walkFn for each item, see Tree.Walk.
+// The walk stops if the walkFn returns an error not nil. The error is propagated by Walk() to the caller.
+func (t *CompactTree) Walk(walkFn CompactWalkFunc) error {
+	var walk func(*compactNode, int) error
+
+	walk = func(node *compactNode, depth int) error {
+		for _, child := range node.childs {
+			if err := walkFn(child.item, depth); err != nil {
+				return err
+			}
+			if err := walk(child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return walk(&t.root, 0)
+}
+
+// Len returns the number of items in the tree.
+func (t *CompactTree) Len() int {
+	var items int
+	_ = t.Walk(func(CompactItem, int) error { items++; return nil })
+	return items
+}
+
+// search returns the position of the first child not less than b, binary search, childs are sorted
+func (node *compactNode) search(b inet.CompactBlock) int {
+	return sort.Search(len(node.childs), func(i int) bool { return node.childs[i].item.Block.Compare(b) >= 0 })
+}
+
+// insertNode, recursive descent, see Node.insertNode
+func (node *compactNode) insertNode(input *compactNode) error {
+	l := len(node.childs)
+	idx := node.search(input.item.Block)
+
+	// don't insert dups
+	if idx < l && input.item.Block.Compare(node.childs[idx].item.Block) == 0 {
+		return fmt.Errorf("duplicate item: %s", input.item)
+	}
+
+	// check if previous child contains this new node
+	if idx > 0 {
+		child := node.childs[idx-1]
+		if child.item.Block.Contains(input.item.Block) {
+			return child.insertNode(input)
+		}
+	}
+
+	// input is greater than all others and not contained in child before, just append
+	if idx == l {
+		node.childs = append(node.childs, input)
+		return nil
+	}
+
+	// insert in place, copy the tail since we insert on the backing array
+	tail := make([]*compactNode, l-idx)
+	copy(tail, node.childs[idx:])
+
+	node.childs = append(node.childs[:idx], input)
+
+	// relink the next childs in row if contained in new input node
+	for j, child := range tail {
+		if input.item.Block.Contains(child.item.Block) {
+			if err := input.insertNode(child); err != nil {
+				return err
+			}
+			continue
+		}
+		// childs are sorted, just copy rest of childs
+		node.childs = append(node.childs, tail[j:]...)
+		break
+	}
+
+	// slice GC gimmick, reset tail elems in base array to nil
+	memclr := node.childs[len(node.childs):cap(node.childs)]
+	for i := range memclr {
+		memclr[i] = nil
+	}
+
+	return nil
+}
+
+// remove, recursive descent, see Node.remove
+func (node *compactNode) remove(input CompactItem) error {
+	l := len(node.childs)
+	idx := node.search(input.Block)
+
+	// check for exact match
+	if idx != l && input.Block.Compare(node.childs[idx].item.Block) == 0 {
+		match := node.childs[idx]
+
+		// cut elem without memory leak
+		copy(node.childs[idx:], node.childs[idx+1:])
+		node.childs[l-1] = nil
+		node.childs = node.childs[:l-1]
+
+		// re-insert grandchilds from deleted child into tree
+		for _, grandChild := range match.childs {
+			if err := node.insertNode(grandChild); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// no exact match at this level, check if child before idx contains the input
+	if idx > 0 {
+		child := node.childs[idx-1]
+		if child.item.Block.Contains(input.Block) {
+			return child.remove(input)
+		}
+	}
+
+	return fmt.Errorf("remove, item not found: %s", input)
+}
@@ -0,0 +1,163 @@
+package tree
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/gaissmai/go-inet/inet"
+	"github.com/gaissmai/go-inet/internal"
+)
+
+// the compact tree must have the same structure and results as the tree
+func TestCompactTreeVersusTree(t *testing.T) {
+	n := 20000
+	blocks := append(internal.GenBlockMixed(n), internal.GenRangeMixed(n)...)
+
+	items := make([]Item, len(blocks))
+	citems := make([]CompactItem, len(blocks))
+	for i := range blocks {
+		items[i] = Item{Block: blocks[i]}
+		citems[i] = CompactItem{Block: blocks[i].Compact()}
+	}
+
+	tr := New()
+	if err := tr.Insert(items...); err != nil {
+		t.Fatalf("Insert error: %s", err)
+	}
+
+	ct := NewCompact()
+	if err := ct.Insert(citems...); err != nil {
+		t.Fatalf("Insert error: %s", err)
+	}
+
+	if got := ct.Len(); got != tr.Len() {
+		t.Errorf("Len() = %d, want %d", got, tr.Len())
+	}
+
+	if got, want := walkString(ct), treeWalkString(tr); got != want {
+		t.Errorf("Walk() differs from tree")
+	}
+
+	// lookup and contains with random queries, hits and misses
+	queries := append(internal.GenBlockMixed(1000), blocks[:1000]...)
+	for _, q := range queries {
+		want, wantOK := tr.Lookup(Item{Block: q})
+		got, ok := ct.Lookup(CompactItem{Block: q.Compact()})
+		if ok != wantOK || got.Block.Block() != want.Block {
+			t.Fatalf("Lookup(%s) = %v, %v, want %v, %v", q, got, ok, want, wantOK)
+		}
+
+		if got, want := ct.Contains(CompactItem{Block: q.Compact()}), tr.Contains(Item{Block: q}); got != want {
+			t.Fatalf("Contains(%s) = %v, want %v", q, got, want)
+		}
+	}
+
+	// remove random items in both trees
+	r := rand.New(rand.NewSource(42))
+	for _, i := range r.Perm(len(blocks))[:len(blocks)/10] {
+		// items are sorted by Insert, take the blocks
+		if err := tr.Remove(Item{Block: blocks[i]}); err != nil {
+			t.Fatalf("Remove error: %s", err)
+		}
+		if err := ct.Remove(CompactItem{Block: blocks[i].Compact()}); err != nil {
+			t.Fatalf("Remove error: %s", err)
+		}
+	}
+
+	if got, want := walkString(ct), treeWalkString(tr); got != want {
+		t.Errorf("Walk() after Remove differs from tree")
+	}
+}
+
+func TestCompactTreeLookupLPM(t *testing.T) {
+	ct := NewCompact()
+
+	for _, s := range []string{
+		"0.0.0.0/8",
+		"1.0.0.0/8",
+		"5.0.0.0/8",
+		"0.0.0.0/0",
+		"::/64",
+		"::/0",
+		"0.0.0.0/10",
+		"10.0.0.1-10.0.0.17",
+	} {
+		if err := ct.Insert(CompactItem{Block: inet.MustBlock(s).Compact(), Payload: s}); err != nil {
+			t.Fatalf("Insert(%s), unexpected error: %v", s, err)
+		}
+	}
+
+	if err := ct.Insert(CompactItem{Block: inet.MustBlock("0.0.0.0/8").Compact()}); err == nil {
+		t.Errorf("Insert(0.0.0.0/8) dup, expected error")
+	}
+
+	for _, tt := range []struct {
+		look, want string
+	}{
+		{"0.0.0.0/32", "0.0.0.0/10"},
+		{"10.0.0.5", "10.0.0.1-10.0.0.17"},
+		{"11.0.0.0/8", "0.0.0.0/0"},
+		{"2001:db8::1", "::/0"},
+		{"::/64", "::/64"},
+	} {
+		got, ok := ct.Lookup(CompactItem{Block: inet.MustBlock(tt.look).Compact()})
+		if !ok || got.Payload != tt.want {
+			t.Errorf("Lookup(%s), got: %v, %v, want: %v", tt.look, got, ok, tt.want)
+		}
+	}
+
+	if err := ct.Remove(CompactItem{Block: inet.MustBlock("0.0.0.0/0").Compact()}); err != nil {
+		t.Fatalf("Remove(0.0.0.0/0), unexpected error: %v", err)
+	}
+
+	look := CompactItem{Block: inet.MustBlock("11.0.0.0/8").Compact()}
+	if got, ok := ct.Lookup(look); ok || got != look {
+		t.Errorf("Lookup(%s), got: %v, %v, want: %v, false", look, got, ok, look)
+	}
+	if ct.Contains(look) {
+		t.Errorf("Contains(%s), got true, want false", look)
+	}
+	if err := ct.Remove(look); err == nil {
+		t.Errorf("Remove(%s), expected error", look)
+	}
+}
+
+func TestCompactTreeWalkStop(t *testing.T) {
+	ct := NewCompact()
+	for _, s := range []string{"10.0.0.0/8", "10.0.0.0/16", "11.0.0.0/8"} {
+		_ = ct.Insert(CompactItem{Block: inet.MustBlock(s).Compact()})
+	}
+
+	var got []string
+	err := ct.Walk(func(item CompactItem, depth int) error {
+		got = append(got, fmt.Sprintf("%d %s", depth, item))
+		if len(got) == 2 {
+			return fmt.Errorf("stop")
+		}
+		return nil
+	})
+
+	if err == nil || strings.Join(got, ", ") != "0 10.0.0.0/8, 1 10.0.0.0/16" {
+		t.Errorf("Walk() stopped with %v, got %v", err, got)
+	}
+}
+
+func walkString(ct *CompactTree) string {
+	w := new(strings.Builder)
+	_ = ct.Walk(func(item CompactItem, depth int) error {
+		fmt.Fprintf(w, "%d %s\n", depth, item)
+		return nil
+	})
+	return w.String()
+}
+
+func treeWalkString(tr *Tree) string {
+	w := new(strings.Builder)
+	_ = tr.Walk(func(node *Node, depth int) error {
+		fmt.Fprintf(w, "%d %s\n", depth, node.Item)
+		return nil
+	})
+	return w.String()
+}